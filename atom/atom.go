@@ -18,7 +18,13 @@
 
 package atom
 
-import "encoding/xml"
+import (
+	"encoding/xml"
+
+	"github.com/racam/clutch/dc"
+	"github.com/racam/clutch/ext"
+	"github.com/racam/clutch/media"
+)
 
 // CommonAttributes is a atom structure like describe in
 // https://tools.ietf.org/html/rfc4287#section-2
@@ -31,40 +37,48 @@ type CommonAttributes struct {
 // https://tools.ietf.org/html/rfc4287#section-4.1.1
 type Feed struct {
 	CommonAttributes
-	Author      []Person   `xml:"author"`
-	Category    []Category `xml:"category"`
-	Contributor []Person   `xml:"contributor"`
-	Entry       []Entry    `xml:"entry"`
-	Generator   Generator  `xml:"generator"`
-	Icon        Icon       `xml:"icon"`
-	IsDeclared  bool       `xml:"-"` //internal field for the checking step
-	ID          ID         `xml:"id"`
-	Link        []Link     `xml:"link"`
-	Logo        Logo       `xml:"logo"`
-	Rights      Text       `xml:"rights"`
-	Subtitle    Text       `xml:"subtitle"`
-	Title       Text       `xml:"title"`
-	Updated     Date       `xml:"updated"`
-	XMLName     xml.Name   `xml:"feed"`
+	Author      []Person       `xml:"author"`
+	Category    []Category     `xml:"category"`
+	Contributor []Person       `xml:"contributor"`
+	DublinCore  dc.DublinCore  `xml:"-"` //Fill with the dc elements
+	Entry       []Entry        `xml:"entry"`
+	Extensions  ext.Extensions `xml:"-"` //Fill with the unknown elements
+	Generator   Generator      `xml:"generator"`
+	Icon        Icon           `xml:"icon"`
+	IsDeclared  bool           `xml:"-"` //internal field for the checking step
+	ID          ID             `xml:"id"`
+	Link        []Link         `xml:"link"`
+	Logo        Logo           `xml:"logo"`
+	Modules     ext.Modules    `xml:"-"` //Fill with the registered modules
+	Rights      Text           `xml:"rights"`
+	Subtitle    Text           `xml:"subtitle"`
+	Title       Text           `xml:"title"`
+	Updated     Date           `xml:"updated"`
+	Version     string         `xml:"-"` //"1.0" or "0.3", fill by Parse
+	XMLName     xml.Name       `xml:"feed"`
 }
 
 // Entry is a atom structure like describe in
 // https://tools.ietf.org/html/rfc4287#section-4.1.2
 type Entry struct {
 	CommonAttributes
-	Author      []Person   `xml:"author"`
-	Category    []Category `xml:"category"`
-	Content     Content    `xml:"content"`
-	Contributor []Person   `xml:"contributor"`
-	ID          ID         `xml:"id"`
-	Link        []Link     `xml:"link"`
-	Published   Date       `xml:"published"`
-	Rights      Text       `xml:"rights"`
-	Source      Source     `xml:"source"`
-	Summary     Text       `xml:"summary"`
-	Title       Text       `xml:"title"`
-	Updated     Date       `xml:"updated"`
-	XMLName     xml.Name   `xml:"entry"`
+	Author      []Person       `xml:"author"`
+	Category    []Category     `xml:"category"`
+	Content     Content        `xml:"content"`
+	Contributor []Person       `xml:"contributor"`
+	DublinCore  dc.DublinCore  `xml:"-"` //Fill with the dc elements
+	Extensions  ext.Extensions `xml:"-"` //Fill with the unknown elements
+	ID          ID             `xml:"id"`
+	Link        []Link         `xml:"link"`
+	Media       media.Media    `xml:"-"` //Fill with the media elements
+	Modules     ext.Modules    `xml:"-"` //Fill with the registered modules
+	Published   Date           `xml:"published"`
+	Rights      Text           `xml:"rights"`
+	Source      Source         `xml:"source"`
+	Summary     Text           `xml:"summary"`
+	Title       Text           `xml:"title"`
+	Updated     Date           `xml:"updated"`
+	XMLName     xml.Name       `xml:"entry"`
 }
 
 // Content is a atom structure like describe in
@@ -95,18 +109,20 @@ type Link struct {
 // https://tools.ietf.org/html/rfc4287#section-4.2.11
 type Source struct {
 	CommonAttributes
-	Author      []Person   `xml:"author"`
-	Category    []Category `xml:"category"`
-	Contributor []Person   `xml:"contributor"`
-	Generator   Generator  `xml:"generator"`
-	Icon        Icon       `xml:"icon"`
-	ID          ID         `xml:"id"`
-	Link        []Link     `xml:"link"`
-	Logo        Logo       `xml:"logo"`
-	Rights      Text       `xml:"rights"`
-	Subtitle    Text       `xml:"subtitle"`
-	Title       Text       `xml:"title"`
-	Updated     Date       `xml:"updated"`
+	Author      []Person       `xml:"author"`
+	Category    []Category     `xml:"category"`
+	Contributor []Person       `xml:"contributor"`
+	Extensions  ext.Extensions `xml:"-"` //Fill with the unknown elements
+	Generator   Generator      `xml:"generator"`
+	Icon        Icon           `xml:"icon"`
+	ID          ID             `xml:"id"`
+	Link        []Link         `xml:"link"`
+	Logo        Logo           `xml:"logo"`
+	Modules     ext.Modules    `xml:"-"` //Fill with the registered modules
+	Rights      Text           `xml:"rights"`
+	Subtitle    Text           `xml:"subtitle"`
+	Title       Text           `xml:"title"`
+	Updated     Date           `xml:"updated"`
 }
 
 // Text is a atom structure like describe in
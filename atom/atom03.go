@@ -0,0 +1,216 @@
+// This file is part of clutch.
+//
+// clutch is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// clutch is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with Foobar.  If not, see <http://www.gnu.org/licenses/>.
+
+// Atom 0.3 is the pre-standard version of Atom, its elements are mapped onto
+// the Atom 1.0 structures.
+// Refer to http://www.mnot.net/drafts/draft-nottingham-atom-format-02.html
+
+package atom
+
+import (
+	"encoding/base64"
+	"strings"
+)
+
+// feed03 is the atom:feed element of Atom 0.3
+type feed03 struct {
+	CommonAttributes
+	Author      []person03  `xml:"author"`
+	Contributor []person03  `xml:"contributor"`
+	Copyright   content03   `xml:"copyright"`
+	Entry       []entry03   `xml:"entry"`
+	Generator   generator03 `xml:"generator"`
+	ID          ID          `xml:"id"`
+	Link        []Link      `xml:"link"`
+	Modified    Date        `xml:"modified"`
+	Tagline     content03   `xml:"tagline"`
+	Title       content03   `xml:"title"`
+}
+
+// entry03 is the atom:entry element of Atom 0.3
+type entry03 struct {
+	CommonAttributes
+	Author      []person03 `xml:"author"`
+	Content     content03  `xml:"content"`
+	Contributor []person03 `xml:"contributor"`
+	Created     Date       `xml:"created"`
+	ID          ID         `xml:"id"`
+	Issued      Date       `xml:"issued"`
+	Link        []Link     `xml:"link"`
+	Modified    Date       `xml:"modified"`
+	Summary     content03  `xml:"summary"`
+	Title       content03  `xml:"title"`
+}
+
+// content03 is the content construct of Atom 0.3, used by atom:content and
+// all the text elements. The type is a MIME type and the mode tells how the
+// content is encoded.
+type content03 struct {
+	CommonAttributes
+	Mode        string `xml:"mode,attr"`
+	TextContent string `xml:",chardata"`
+	Type        string `xml:"type,attr"`
+	XMLContent  string `xml:",innerxml"`
+}
+
+// person03 is the person construct of Atom 0.3, the uri element was named url
+type person03 struct {
+	CommonAttributes
+	Email string `xml:"email"`
+	Name  string `xml:"name"`
+	URL   string `xml:"url"`
+}
+
+// generator03 is the atom:generator element of Atom 0.3, the uri attribute
+// was named url
+type generator03 struct {
+	CommonAttributes
+	Content string `xml:",chardata"`
+	URL     string `xml:"url,attr"`
+	Version string `xml:"version,attr"`
+}
+
+func (f *feed03) toFeed() Feed {
+	res := Feed{
+		CommonAttributes: f.CommonAttributes,
+		Author:           toPersons(f.Author),
+		Contributor:      toPersons(f.Contributor),
+		Generator: Generator{
+			CommonAttributes: f.Generator.CommonAttributes,
+			Content:          f.Generator.Content,
+			URI:              f.Generator.URL,
+			Version:          f.Generator.Version,
+		},
+		ID:       f.ID,
+		Link:     f.Link,
+		Rights:   f.Copyright.toText(),
+		Subtitle: f.Tagline.toText(),
+		Title:    f.Title.toText(),
+		Updated:  f.Modified,
+	}
+
+	for index := range f.Entry {
+		res.Entry = append(res.Entry, f.Entry[index].toEntry())
+	}
+
+	return res
+}
+
+func (e *entry03) toEntry() Entry {
+	res := Entry{
+		CommonAttributes: e.CommonAttributes,
+		Author:           toPersons(e.Author),
+		Content:          e.Content.toContent(),
+		Contributor:      toPersons(e.Contributor),
+		ID:               e.ID,
+		Link:             e.Link,
+		Published:        e.Issued,
+		Summary:          e.Summary.toText(),
+		Title:            e.Title.toText(),
+		Updated:          e.Modified,
+	}
+
+	// atom:issued is required but some publishers only give atom:created
+	if res.Published.DateTime == "" {
+		res.Published = e.Created
+	}
+
+	return res
+}
+
+func toPersons(persons []person03) []Person {
+	var res []Person
+	for _, p := range persons {
+		res = append(res, Person{
+			CommonAttributes: p.CommonAttributes,
+			Email:            p.Email,
+			Name:             p.Name,
+			URI:              p.URL,
+		})
+	}
+
+	return res
+}
+
+// toContent converts the content construct. The MIME types of text, html and
+// xhtml become the Atom 1.0 types, escaped and base64 contents are decoded
+// unless they are binary data which stays base64 encoded like in Atom 1.0.
+func (c *content03) toContent() Content {
+	res := Content{CommonAttributes: c.CommonAttributes}
+	res.Text.CommonAttributes = c.CommonAttributes
+	res.Type = contentType03(c.Type)
+
+	switch strings.ToLower(c.Mode) {
+	case "escaped":
+		res.TextContent = c.TextContent
+	case "base64":
+		res.TextContent = c.TextContent
+		if res.Type == text || res.Type == html ||
+			strings.HasPrefix(res.Type, "text/") {
+			decoded, err := base64.StdEncoding.DecodeString(
+				strings.TrimSpace(c.TextContent))
+			if err == nil {
+				res.TextContent = string(decoded)
+			}
+		}
+	default: // "xml"
+		res.TextContent = c.TextContent
+		res.XMLContent = c.XMLContent
+		// Inline html is not escaped, it is kept as xhtml
+		if res.Type == html && hasChildElements(c.XMLContent) {
+			res.Type = xhtml
+		}
+	}
+
+	return res
+}
+
+// toText converts the content construct into a text construct
+func (c *content03) toText() Text {
+	content := c.toContent()
+	res := content.Text
+
+	switch content.Type {
+	case "", text, html, xhtml:
+		res.Type = content.Type
+	default:
+		res.Type = text
+	}
+
+	return res
+}
+
+// hasChildElements returns true if the inner xml contains markup other than
+// CDATA sections
+func hasChildElements(innerXML string) bool {
+	return strings.Contains(strings.Replace(innerXML, "<![CDATA[", "", -1), "<")
+}
+
+// contentType03 converts a MIME type of Atom 0.3 into a type of Atom 1.0. An
+// absent type stays empty, parseContent applies the default.
+func contentType03(mimeType string) string {
+	switch strings.ToLower(strings.TrimSpace(mimeType)) {
+	case "":
+		return ""
+	case "text/plain":
+		return text
+	case "text/html":
+		return html
+	case "application/xhtml+xml":
+		return xhtml
+	}
+
+	return mimeType
+}
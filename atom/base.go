@@ -0,0 +1,260 @@
+// This file is part of clutch.
+//
+// clutch is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// clutch is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with Foobar.  If not, see <http://www.gnu.org/licenses/>.
+package atom
+
+import (
+	"net/url"
+	"regexp"
+	"strings"
+)
+
+// ResolveURLs resolves in place the relative references of the document :
+// the href of the links, the URI of the icons, the logos, the generators and
+// the persons, the src of the contents and the href and src attributes of the
+// xhtml contents. The base URI of an element is its xml:base attribute,
+// resolved against the base URI of its parent, from the feed to the entries
+// and their elements; document, the URL the document was retrieved from, is
+// the base URI of the feed. It may be nil, the references are then resolved
+// against the xml:base attributes only and may stay relative.
+// An invalid xml:base attribute is ignored, an invalid reference is kept.
+// source : https://tools.ietf.org/html/rfc4287#section-2
+// source : https://www.w3.org/TR/xmlbase/
+// source : https://tools.ietf.org/html/rfc3986#section-5
+func (f *Feed) ResolveURLs(document *url.URL) {
+	if document == nil {
+		document = &url.URL{}
+	}
+
+	base := withBase(document, f.Base)
+
+	for index := range f.Author {
+		f.Author[index].resolve(base)
+	}
+
+	for index := range f.Contributor {
+		f.Contributor[index].resolve(base)
+	}
+
+	for index := range f.Entry {
+		f.Entry[index].resolve(base)
+	}
+
+	for index := range f.Link {
+		f.Link[index].resolve(base)
+	}
+
+	f.Generator.resolve(base)
+	(*CommonURI)(&f.Icon).resolve(base)
+	(*CommonURI)(&f.Logo).resolve(base)
+	f.Rights.resolve(base)
+	f.Subtitle.resolve(base)
+	f.Title.resolve(base)
+}
+
+func (e *Entry) resolve(parent *url.URL) {
+	base := withBase(parent, e.Base)
+
+	for index := range e.Author {
+		e.Author[index].resolve(base)
+	}
+
+	for index := range e.Contributor {
+		e.Contributor[index].resolve(base)
+	}
+
+	for index := range e.Link {
+		e.Link[index].resolve(base)
+	}
+
+	e.Content.resolve(base)
+	e.Rights.resolve(base)
+	e.Source.resolve(base)
+	e.Summary.resolve(base)
+	e.Title.resolve(base)
+}
+
+func (s *Source) resolve(parent *url.URL) {
+	base := withBase(parent, s.Base)
+
+	for index := range s.Author {
+		s.Author[index].resolve(base)
+	}
+
+	for index := range s.Contributor {
+		s.Contributor[index].resolve(base)
+	}
+
+	for index := range s.Link {
+		s.Link[index].resolve(base)
+	}
+
+	s.Generator.resolve(base)
+	(*CommonURI)(&s.Icon).resolve(base)
+	(*CommonURI)(&s.Logo).resolve(base)
+	s.Rights.resolve(base)
+	s.Subtitle.resolve(base)
+	s.Title.resolve(base)
+}
+
+func (c *CommonURI) resolve(parent *url.URL) {
+	c.URI = resolve(withBase(parent, c.Base), c.URI)
+}
+
+func (c *Content) resolve(parent *url.URL) {
+	base := withBase(parent, c.CommonAttributes.Base)
+
+	c.Src = resolve(base, c.Src)
+	if c.Type == xhtml {
+		c.Content = resolveXHTML(base, c.Content)
+	}
+}
+
+func (g *Generator) resolve(parent *url.URL) {
+	g.URI = resolve(withBase(parent, g.Base), g.URI)
+}
+
+func (l *Link) resolve(parent *url.URL) {
+	l.Href = resolve(withBase(parent, l.Base), l.Href)
+}
+
+func (p *Person) resolve(parent *url.URL) {
+	p.URI = resolve(withBase(parent, p.Base), p.URI)
+}
+
+func (t *Text) resolve(parent *url.URL) {
+	if t.Type == xhtml {
+		t.Content = resolveXHTML(withBase(parent, t.Base), t.Content)
+	}
+}
+
+// withBase returns the base URI of an element, its xml:base attribute
+// resolved against the base URI of its parent
+func withBase(parent *url.URL, attr string) *url.URL {
+	if attr == "" {
+		return parent
+	}
+
+	ref, err := url.Parse(strings.TrimSpace(attr))
+	if err != nil {
+		return parent
+	}
+
+	return resolveReference(parent, ref)
+}
+
+// resolve returns the reference resolved against the base URI, the empty and
+// the invalid references are returned unchanged
+func resolve(base *url.URL, reference string) string {
+	if reference == "" || *base == (url.URL{}) {
+		return reference
+	}
+
+	ref, err := url.Parse(strings.TrimSpace(reference))
+	if err != nil {
+		return reference
+	}
+
+	return resolveReference(base, ref).String()
+}
+
+// resolveReference resolves the reference against the base URI. Unlike
+// url.ResolveReference, a relative base, e.g. an xml:base attribute without a
+// document URL, gives a relative reference instead of an absolute path.
+func resolveReference(base *url.URL, ref *url.URL) *url.URL {
+	if base.Scheme != "" || base.Host != "" ||
+		strings.HasPrefix(base.Path, "/") {
+		return base.ResolveReference(ref)
+	}
+
+	if ref.Scheme != "" || ref.Host != "" || strings.HasPrefix(ref.Path, "/") ||
+		*base == (url.URL{}) {
+		return ref
+	}
+
+	res := *ref
+	res.RawPath = ""
+	if ref.Path != "" {
+		res.Path = relativePath(base.Path, ref.Path)
+	} else {
+		res.Path = base.Path
+		if ref.RawQuery == "" && !ref.ForceQuery {
+			res.RawQuery = base.RawQuery
+		}
+	}
+
+	return &res
+}
+
+// relativePath merges the relative path of a reference with the relative
+// path of its base and removes the dot segments, like RFC 3986. The ".."
+// segments which go above the base are kept, e.g. ../media/ and a.mp3 give
+// ../media/a.mp3.
+// source : https://tools.ietf.org/html/rfc3986#section-5.2.4
+func relativePath(base string, ref string) string {
+	segments := strings.Split(base[:strings.LastIndex(base, "/")+1]+ref, "/")
+
+	var res []string
+	for index, s := range segments {
+		switch {
+		case s == ".":
+		case s == ".." && len(res) > 0 && res[len(res)-1] != "..":
+			res = res[:len(res)-1]
+		default:
+			res = append(res, s)
+		}
+
+		// a path ending with a dot segment is a directory
+		if (s == "." || s == "..") && index == len(segments)-1 {
+			res = append(res, "")
+		}
+	}
+
+	if path := strings.Join(res, "/"); path != "" {
+		return path
+	}
+	return "./"
+}
+
+// xhtmlURL matches the href and src attributes of the elements of an xhtml
+// content, with their quoted value
+var xhtmlURL = regexp.MustCompile(`(\s(?:href|src)\s*=\s*)(?:"([^"]*)"|'([^']*)')`)
+
+// xmlUnescaper and xmlEscaper convert the value of an attribute, quoted with
+// ", from and to the XML syntax
+var (
+	xmlUnescaper = strings.NewReplacer("&lt;", "<", "&gt;", ">", "&quot;", `"`,
+		"&apos;", "'", "&amp;", "&")
+	xmlEscaper = strings.NewReplacer("&", "&amp;", "<", "&lt;", `"`, "&quot;")
+)
+
+// resolveXHTML resolves the href and src attributes of the elements of an
+// xhtml content against the base URI. The xml:base attributes inside the
+// content are not taken into account.
+func resolveXHTML(base *url.URL, content string) string {
+	if *base == (url.URL{}) {
+		return content
+	}
+
+	return xhtmlURL.ReplaceAllStringFunc(content, func(attr string) string {
+		match := xhtmlURL.FindStringSubmatch(attr)
+		value := xmlUnescaper.Replace(match[2] + match[3])
+
+		resolved := resolve(base, value)
+		if resolved == value {
+			return attr
+		}
+		return match[1] + `"` + xmlEscaper.Replace(resolved) + `"`
+	})
+}
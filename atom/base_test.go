@@ -0,0 +1,124 @@
+// This file is part of clutch.
+//
+// clutch is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// clutch is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with Foobar.  If not, see <http://www.gnu.org/licenses/>.
+package atom
+
+import (
+	"io/ioutil"
+	"net/url"
+	"strings"
+	"testing"
+)
+
+func TestResolveURLs(t *testing.T) {
+	filename := "unit_33_resolve_urls.xml"
+	data, err := ioutil.ReadFile(prefix + filename)
+	if err != nil {
+		t.Fatalf("[Atom][Unit][Resolve] file '%s' : is missing",
+			prefix+filename)
+	}
+
+	document, _ := url.Parse("http://example.org/feeds/atom.xml")
+
+	var tests = []struct {
+		document *url.URL
+		expected []string // link, icon, logo, uri, entry links, a, img, src
+	}{
+		{nil, []string{"blog/index.html", "blog/favicon.ico",
+			"/images/logo.png", "blog/about/", "blog/2006/post.html",
+			"blog/archives", "blog/2006/a.html?x=1&amp;y=2",
+			"blog/2006/b.png", "http://cdn.example.org/media/video.mp4"}},
+		{document, []string{"http://example.org/feeds/blog/index.html",
+			"http://example.org/feeds/blog/favicon.ico",
+			"http://example.org/images/logo.png",
+			"http://example.org/feeds/blog/about/",
+			"http://example.org/feeds/blog/2006/post.html",
+			"http://example.org/feeds/blog/archives",
+			"http://example.org/feeds/blog/2006/a.html?x=1&amp;y=2",
+			"http://example.org/feeds/blog/2006/b.png",
+			"http://cdn.example.org/media/video.mp4"}},
+	}
+
+	for _, test := range tests {
+		f, err := Parse(data)
+		if err != nil {
+			t.Fatalf("[Atom][Unit][Resolve] file '%s' : %s", filename, err)
+		}
+
+		f.ResolveURLs(test.document)
+
+		e := f.Entry[0]
+		actual := []string{f.Link[0].Href, f.Icon.URI, f.Logo.URI,
+			f.Author[0].URI, e.Link[0].Href, e.Link[1].Href}
+		for index, value := range actual {
+			if value != test.expected[index] {
+				t.Errorf("[Atom][Unit][Resolve] document %v : expected '%s', "+
+					"actual '%s'", test.document, test.expected[index], value)
+			}
+		}
+
+		if e.Link[2].Href != "http://example.com/" {
+			t.Errorf("[Atom][Unit][Resolve] absolute link : unexpected '%s'",
+				e.Link[2].Href)
+		}
+
+		if !strings.Contains(e.Content.Content,
+			`<a href="`+test.expected[6]+`">`) ||
+			!strings.Contains(e.Content.Content,
+				`<img src="`+test.expected[7]+`"/>`) ||
+			!strings.Contains(e.Content.Content,
+				`<a href="http://example.com/c">`) {
+			t.Errorf("[Atom][Unit][Resolve] document %v : unexpected content "+
+				"%s", test.document, e.Content.Content)
+		}
+
+		if f.Entry[1].Content.Src != test.expected[8] {
+			t.Errorf("[Atom][Unit][Resolve] document %v : unexpected src '%s'",
+				test.document, f.Entry[1].Content.Src)
+		}
+	}
+}
+
+func TestResolveReference(t *testing.T) {
+	var tests = []struct {
+		base     string
+		ref      string
+		expected string
+	}{
+		{"", "a.html", "a.html"},
+		{"", "", ""},
+		{"http://example.org/a/b", "", ""},
+		{"http://example.org/a/b", "c", "http://example.org/a/c"},
+		{"http://example.org/a/b", "../c", "http://example.org/c"},
+		{"http://example.org/a/b", "//example.com/c", "http://example.com/c"},
+		{"http://example.org/a/b", "#top", "http://example.org/a/b#top"},
+		{"http://example.org/a/b", "mailto:john@example.org",
+			"mailto:john@example.org"},
+		{"a/b/", "../c", "a/c"},
+		{"a/", "/c", "/c"},
+		{"../media/", "a.mp3", "../media/a.mp3"},
+		{"../", "../a", "../../a"},
+		{"a/b", "./", "a/"},
+		{"a/", "..", "./"},
+		{"a/b?x=1", "#top", "a/b?x=1#top"},
+	}
+
+	for _, test := range tests {
+		base, _ := url.Parse(test.base)
+		if actual := resolve(base, test.ref); actual != test.expected {
+			t.Errorf("[Atom][Unit][Resolve] '%s' against '%s' : expected '%s'"+
+				", actual '%s'", test.ref, test.base, test.expected, actual)
+		}
+	}
+}
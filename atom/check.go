@@ -16,288 +16,570 @@
 package atom
 
 import (
-	"errors"
+	"bytes"
+	"encoding/xml"
+	"io"
+	"net/url"
+	"strconv"
 	"strings"
+
+	"github.com/racam/clutch/date"
+	"github.com/racam/clutch/internal/xmldec"
+	"github.com/racam/clutch/validation"
 )
 
-// Check verifies all the requierements mentioned by the ATOM RFC4287. i.e
-// all the required fields/attributes are here and just once if unique.
+// ValidationReport lists the violations of the ATOM RFC4287 found in a
+// feed, see validation.Report
+type ValidationReport = validation.Report
+
+// Check verifies all the requierements mentioned by the ATOM RFC4287 and
+// returns the first violation, see Validate
 func Check(f *Feed) error {
-	return f.check()
+	return Validate(f).Err()
+}
+
+// Validate verifies all the requierements mentioned by the ATOM RFC4287. i.e
+// all the required fields/attributes are here and just once if unique, and
+// returns every violation. Atom 0.3 documents are checked with the rules of
+// the 0.3 draft where they differ from the RFC.
+// The feed is checked as it is in memory : the positions are unknown and the
+// duplicated elements merged by the parsing are not seen, ValidateDocument
+// checks the source document.
+func Validate(f *Feed) *ValidationReport {
+	v := validator{is03: f.Version == "0.3", report: &ValidationReport{}}
+	f.check(&v)
+	return v.report
+}
+
+// ValidateDocument parses the ATOM-encoded data and validates it like
+// Validate, the violations carry the line and the column of the elements. An
+// error is returned if the data can not be parsed.
+func ValidateDocument(data []byte) (*ValidationReport, error) {
+	f, err := Parse(data)
+	if err != nil {
+		return nil, err
+	}
+
+	positions, err := xmldec.Record(bytes.NewReader(data))
+	if err != nil {
+		return nil, err
+	}
+
+	v := validator{is03: f.Version == "0.3", positions: positions,
+		report: &ValidationReport{}}
+	f.check(&v)
+	return v.report, nil
+}
+
+// validator fills the report, the positions are nil when the source is
+// unknown
+type validator struct {
+	is03      bool
+	positions *xmldec.Positions
+	report    *ValidationReport
+}
+
+// error adds the violation of a MUST rule of the section of RFC 4287 by the
+// element at the path
+func (v *validator) error(path string, section string, message string) {
+	line, column := v.positions.Position(path)
+
+	v.report.Add(validation.Violation{
+		Column:   column,
+		Line:     line,
+		Message:  message,
+		Path:     path,
+		Section:  section,
+		Severity: validation.SeverityError,
+		Spec:     "RFC 4287",
+	})
+}
+
+// error03 adds the violation of a rule of the Atom 0.3 draft, where it differs
+// from RFC 4287
+// source : http://www.mnot.net/drafts/draft-nottingham-atom-format-02.html
+func (v *validator) error03(path string, message string) {
+	line, column := v.positions.Position(path)
+
+	v.report.Add(validation.Violation{
+		Column:   column,
+		Line:     line,
+		Message:  message,
+		Path:     path,
+		Severity: validation.SeverityError,
+		Spec:     "draft-nottingham-atom-format-02",
+	})
 }
 
-func (f *Feed) check() error {
-	if f.IsDeclared {
-		if f.ID.URI == "" {
-			return errors.New("atom:feed elements MUST contain exactly one " +
-				"atom:id element.")
+// checkOnce verifies that the element at the path has at most one child of
+// each name. The duplicates are merged by the parsing, so they are only seen
+// when the source document is validated. The 0.3 elements have other names,
+// they are not checked.
+func (v *validator) checkOnce(path string, section string, parent string,
+	names ...string) {
+	if v.is03 {
+		return
+	}
+
+	for _, name := range names {
+		if v.positions.Count(path, name) > 1 {
+			v.error(item(path, name, 1), section, parent+" elements MUST "+
+				"NOT contain more than one atom:"+name+" element.")
 		}
+	}
+}
+
+// checkDate verifies that the date respects RFC 3339, Atom 0.3 dates are
+// W3C-DTF dates and are not checked
+// https://tools.ietf.org/html/rfc4287#section-3.3
+func (v *validator) checkDate(path string, d *Date) {
+	if v.is03 || d.DateTime == "" {
+		return
+	}
+
+	if _, err := date.ParseRFC3339(d.DateTime); err != nil {
+		v.error(path, "3.3", "Date constructs MUST conform to the "+
+			"\"date-time\" production in RFC3339, actual '"+d.DateTime+"'.")
+	}
+}
+
+// checkID verifies that the id is an absolute IRI
+// https://tools.ietf.org/html/rfc4287#section-4.2.6
+func (v *validator) checkID(path string, id *ID) {
+	if id.URI == "" {
+		return
+	}
+
+	u, err := url.Parse(id.URI)
+	if err != nil || !u.IsAbs() || strings.ContainsAny(id.URI, " \t\r\n") {
+		v.error(path, "4.2.6", "atom:id content MUST be an IRI, and MUST NOT "+
+			"be a relative reference, actual '"+id.URI+"'.")
+	}
+}
+
+// checkAlternate verifies that the alternate links have different
+// combinations of type and hreflang
+func (v *validator) checkAlternate(path string, section string, parent string,
+	links []Link) {
+	seen := map[string]bool{}
 
-		if f.Title.Content == "" {
-			return errors.New("atom:feed elements MUST contain exactly one " +
-				"atom:title element.")
+	for index, l := range links {
+		if l.Rel != "alternate" {
+			continue
 		}
 
-		if f.Updated.DateTime == "" {
-			return errors.New("atom:feed elements MUST contain exactly one " +
-				"atom:updated element.")
+		key := l.Type + " " + l.Hreflang
+		if seen[key] {
+			v.error(item(path, "link", index), section, parent+" elements "+
+				"MUST NOT contain more than one atom:link element with a rel "+
+				"attribute value of 'alternate' that has the same "+
+				"combination of type and hreflang attribute values.")
 		}
+		seen[key] = true
 	}
+}
 
-	for _, c := range f.Category {
-		err := c.check()
-		if err != nil {
-			return errors.New("atom:feed " + err.Error())
+// item returns the path of the repeatable child element at the index
+func item(path string, name string, index int) string {
+	return path + "/" + name + "[" + strconv.Itoa(index+1) + "]"
+}
+
+func (f *Feed) check(v *validator) {
+	// An Atom Entry Document holds a single entry at the root
+	if !f.IsDeclared {
+		for index := range f.Entry {
+			f.Entry[index].check(v, "/entry", true)
 		}
+		return
 	}
 
-	for _, l := range f.Link {
-		err := l.check()
-		if err != nil {
-			return errors.New("atom:feed " + err.Error())
-		}
+	path := "/feed"
+
+	// http://www.mnot.net/drafts/draft-nottingham-atom-format-02.html the id
+	// is optional in Atom 0.3 but a link is required
+	if v.is03 && len(f.Link) == 0 {
+		v.error03(path, "atom:feed elements MUST contain at least one "+
+			"atom:link element.")
 	}
 
-	for _, a := range f.Author {
-		err := a.check()
-		if err != nil {
-			return errors.New("atom:feed atom:auhtor " + err.Error())
-		}
+	// https://tools.ietf.org/html/rfc4287#section-4.1.1
+	if !v.is03 && f.ID.URI == "" {
+		v.error(path, "4.1.1", "atom:feed elements MUST contain exactly one "+
+			"atom:id element.")
 	}
-	// atom:feed elements MUST contain one or more atom:author elements,
-	// unless all of the atom:feed element's child atom:entry elements
-	// contain at least one atom:author element.
-	requiredAuthor := len(f.Author) == 0
 
-	for _, c := range f.Contributor {
-		err := c.check()
-		if err != nil {
-			return errors.New("atom:feed atom:contributor " + err.Error())
-		}
+	if f.Title.Content == "" {
+		v.error(path, "4.1.1", "atom:feed elements MUST contain exactly one "+
+			"atom:title element.")
 	}
 
-	if err := f.Rights.check(); err != nil {
-		return errors.New("atom:feed atom:rights " + err.Error())
+	if f.Updated.DateTime == "" {
+		v.error(path, "4.1.1", "atom:feed elements MUST contain exactly one "+
+			updatedName(v.is03)+" element.")
 	}
 
-	if err := f.Subtitle.check(); err != nil {
-		return errors.New("atom:feed atom:subtitle " + err.Error())
+	v.checkOnce(path, "4.1.1", "atom:feed", "generator", "icon", "id", "logo",
+		"rights", "subtitle", "title", "updated")
+	v.checkAlternate(path, "4.1.1", "atom:feed", f.Link)
+	v.checkID(path+"/id", &f.ID)
+	v.checkDate(path+"/updated", &f.Updated)
+
+	for index := range f.Category {
+		f.Category[index].check(v, item(path, "category", index))
 	}
 
-	if err := f.Title.check(); err != nil {
-		return errors.New("atom:feed atom:title " + err.Error())
+	for index := range f.Link {
+		f.Link[index].check(v, item(path, "link", index))
 	}
 
-	for _, entry := range f.Entry {
-		err := entry.check(requiredAuthor)
-		if err != nil {
-			return errors.New("atom:feed " + err.Error())
-		}
+	for index := range f.Author {
+		f.Author[index].check(v, item(path, "author", index))
 	}
+	// atom:feed elements MUST contain one or more atom:author elements,
+	// unless all of the atom:feed element's child atom:entry elements
+	// contain at least one atom:author element.
+	requiredAuthor := len(f.Author) == 0
+
+	for index := range f.Contributor {
+		f.Contributor[index].check(v, item(path, "contributor", index))
+	}
+
+	f.Rights.check(v, path+"/rights")
+	f.Subtitle.check(v, path+"/subtitle")
+	f.Title.check(v, path+"/title")
 
-	return nil
+	for index := range f.Entry {
+		f.Entry[index].check(v, item(path, "entry", index), requiredAuthor)
+	}
 }
 
-func (e *Entry) check(requiredAuthor bool) error {
+func (e *Entry) check(v *validator, path string, requiredAuthor bool) {
+	// https://tools.ietf.org/html/rfc4287#section-4.1.2
 	if e.ID.URI == "" {
-		return errors.New("atom:entry elements MUST contain exactly one " +
+		v.error(path, "4.1.2", "atom:entry elements MUST contain exactly one "+
 			"atom:id element.")
 	}
 
 	if e.Title.Content == "" {
-		return errors.New("atom:entry elements MUST contain exactly one " +
+		v.error(path, "4.1.2", "atom:entry elements MUST contain exactly one "+
 			"atom:title element.")
 	}
 
 	if e.Updated.DateTime == "" {
-		return errors.New("atom:entry elements MUST contain exactly one " +
-			"atom:updated element.")
+		v.error(path, "4.1.2", "atom:entry elements MUST contain exactly one "+
+			updatedName(v.is03)+" element.")
 	}
 
-	for _, c := range e.Category {
-		err := c.check()
-		if err != nil {
-			return errors.New("atom:entry " + err.Error())
-		}
+	// atom:issued is mapped onto Published
+	if v.is03 && e.Published.DateTime == "" {
+		v.error03(path, "atom:entry elements MUST contain exactly one "+
+			"atom:issued element.")
 	}
 
-	for _, c := range e.Contributor {
-		err := c.check()
-		if err != nil {
-			return errors.New("atom:entry atom:contributor" + err.Error())
-		}
+	v.checkOnce(path, "4.1.2", "atom:entry", "content", "id", "published",
+		"rights", "source", "summary", "title", "updated")
+	v.checkAlternate(path, "4.1.2", "atom:entry", e.Link)
+	v.checkID(path+"/id", &e.ID)
+	v.checkDate(path+"/published", &e.Published)
+	v.checkDate(path+"/updated", &e.Updated)
+
+	// atom:entry elements that contain no child atom:content element MUST
+	// contain at least one atom:link element with a rel attribute value of
+	// "alternate".
+	if !e.Content.present() && !hasAlternate(e.Link) {
+		v.error(path, "4.1.2", "atom:entry elements that contain no child "+
+			"atom:content element MUST contain at least one atom:link "+
+			"element with a rel attribute value of 'alternate'.")
 	}
 
-	for _, a := range e.Author {
-		err := a.check()
-		if err != nil {
-			return errors.New("atom:entry atom:author" + err.Error())
-		}
+	// atom:entry elements MUST contain an atom:summary element if the
+	// content has a src attribute or is encoded in Base64
+	if (e.Content.Src != "" || e.Content.isBase64()) && !e.Summary.present() {
+		v.error(path, "4.1.2", "atom:entry elements MUST contain an "+
+			"atom:summary element when the atom:content has a 'src' "+
+			"attribute or is encoded in Base64.")
 	}
 
-	// atom:entry elements MUST contain one or more atom:author elements,
-	// unless the atom:entry contains an atom:source element that
-	// contains an atom:author element or, in an Atom Feed Document, the
-	// atom:feed element contains an atom:author element itself.
-	requiredAuthor = requiredAuthor && len(e.Author) == 0
+	for index := range e.Category {
+		e.Category[index].check(v, item(path, "category", index))
+	}
 
-	if err := e.Source.check(requiredAuthor); err != nil {
-		return errors.New("atom:entry " + err.Error())
+	for index := range e.Contributor {
+		e.Contributor[index].check(v, item(path, "contributor", index))
 	}
 
-	if err := e.Rights.check(); err != nil {
-		return errors.New("atom:entry atom:rights " + err.Error())
+	for index := range e.Author {
+		e.Author[index].check(v, item(path, "author", index))
 	}
 
-	if err := e.Summary.check(); err != nil {
-		return errors.New("atom:entry atom:summary " + err.Error())
+	for index := range e.Link {
+		e.Link[index].check(v, item(path, "link", index))
 	}
 
-	if err := e.Title.check(); err != nil {
-		return errors.New("atom:entry atom:title " + err.Error())
+	// atom:entry elements MUST contain one or more atom:author elements,
+	// unless the atom:entry contains an atom:source element that
+	// contains an atom:author element or, in an Atom Feed Document, the
+	// atom:feed element contains an atom:author element itself.
+	requiredAuthor = requiredAuthor && len(e.Author) == 0
+	if requiredAuthor && len(e.Source.Author) == 0 {
+		v.error(path, "4.1.2", "There is no atom:author. It MUST have a "+
+			"least one in atom:feed, atom:entry or atom:source.")
 	}
 
-	return nil
+	e.Content.check(v, path+"/content")
+	e.Source.check(v, path+"/source")
+	e.Rights.check(v, path+"/rights")
+	e.Summary.check(v, path+"/summary")
+	e.Title.check(v, path+"/title")
 }
 
-func (s *Source) check(requiredAuthor bool) error {
-
-	if requiredAuthor && len(s.Author) == 0 {
-		return errors.New("atom:source. There is no atom:author. It MUST " +
-			"have a least one in atom:feed, atom:entry or atom:source.")
-	}
-
-	for _, a := range s.Author {
-		err := a.check()
-		if err != nil {
-			return errors.New("atom:source atom:author" + err.Error())
+// hasAlternate returns true if one of the links is an alternate link
+func hasAlternate(links []Link) bool {
+	for _, l := range links {
+		if l.Rel == "alternate" {
+			return true
 		}
 	}
 
-	for _, c := range s.Category {
-		err := c.check()
-		if err != nil {
-			return errors.New("atom:source " + err.Error())
-		}
-	}
+	return false
+}
 
-	for _, c := range s.Contributor {
-		err := c.check()
-		if err != nil {
-			return errors.New("atom:source atom:contributor" + err.Error())
-		}
+// https://tools.ietf.org/html/rfc4287#section-4.2.11
+func (s *Source) check(v *validator, path string) {
+	v.checkOnce(path, "4.2.11", "atom:source", "generator", "icon", "id",
+		"logo", "rights", "subtitle", "title", "updated")
+	v.checkID(path+"/id", &s.ID)
+	v.checkDate(path+"/updated", &s.Updated)
+
+	for index := range s.Author {
+		s.Author[index].check(v, item(path, "author", index))
 	}
 
-	for _, l := range s.Link {
-		err := l.check()
-		if err != nil {
-			return errors.New("atom:source " + err.Error())
-		}
+	for index := range s.Category {
+		s.Category[index].check(v, item(path, "category", index))
 	}
 
-	if err := s.Rights.check(); err != nil {
-		return errors.New("atom:source atom:rights" + err.Error())
+	for index := range s.Contributor {
+		s.Contributor[index].check(v, item(path, "contributor", index))
 	}
 
-	if err := s.Subtitle.check(); err != nil {
-		return errors.New("atom:source atom:subtitle" + err.Error())
+	for index := range s.Link {
+		s.Link[index].check(v, item(path, "link", index))
 	}
 
-	if err := s.Title.check(); err != nil {
-		return errors.New("atom:source atom:title" + err.Error())
+	s.Rights.check(v, path+"/rights")
+	s.Subtitle.check(v, path+"/subtitle")
+	s.Title.check(v, path+"/title")
+}
+
+// updatedName returns the name of the element mapped onto Updated
+func updatedName(is03 bool) string {
+	if is03 {
+		return "atom:modified"
 	}
 
-	return nil
+	return "atom:updated"
 }
 
-func (c *Category) check() error {
+func (c *Category) check(v *validator, path string) {
 
 	// https://tools.ietf.org/html/rfc4287#section-4.2.2.1
 	if c.Term == "" {
-		return errors.New("atom:category elements MUST have a 'term' attribute")
+		v.error(path, "4.2.2.1", "atom:category elements MUST have a 'term' "+
+			"attribute")
 	}
+}
+
+// present returns false if the entry has no atom:content element
+func (c *Content) present() bool {
+	return c.Src != "" || c.Type != "" || c.XMLContent != ""
+}
+
+// isBase64 returns true if the content is encoded in Base64, i.e. its type is
+// a MIME media type which is not an XML media type and does not begin with
+// "text/"
+// https://tools.ietf.org/html/rfc4287#section-4.1.3.3
+func (c *Content) isBase64() bool {
+	lowerType := strings.ToLower(c.Type)
 
-	return nil
+	return isMediaType(lowerType) && !strings.HasPrefix(lowerType, "text/") &&
+		!strings.HasSuffix(lowerType, "/xml") &&
+		!strings.HasSuffix(lowerType, "+xml")
 }
 
-func (c *Content) check() error {
+func (c *Content) check(v *validator, path string) {
+	if !c.present() {
+		return
+	}
+
+	// https://tools.ietf.org/html/rfc4287#section-4.1.3.1
+	if c.Type != "" && c.Type != text && c.Type != html && c.Type != xhtml {
+		lowerType := strings.ToLower(c.Type)
+		if !isMediaType(lowerType) ||
+			strings.HasPrefix(lowerType, "multipart/") ||
+			strings.HasPrefix(lowerType, "message/") {
+			v.error(path, "4.1.3.1", "atom:content 'type' MUST be one of "+
+				"'text', 'html', or 'xhtml' or a MIME media type which is not "+
+				"a composite type, actual '"+c.Type+"'.")
+		}
+	}
 
 	// https://tools.ietf.org/html/rfc4287#section-4.1.3.2
 	if c.Src != "" && c.Content != "" {
-		return errors.New("atom:content, If the 'src' attribute is present, " +
-			"atom:content MUST be empty.")
+		v.error(path, "4.1.3.2", "atom:content, If the 'src' attribute is "+
+			"present, atom:content MUST be empty.")
+	}
+
+	if c.Src != "" && (c.Type == text || c.Type == html || c.Type == xhtml) {
+		v.error(path, "4.1.3.2", "atom:content, If the 'src' attribute is "+
+			"present, the 'type' attribute MUST be a MIME media type, "+
+			"rather than 'text', 'html', or 'xhtml'.")
 	}
 
 	// https://tools.ietf.org/html/rfc4287#section-4.1.3.2 RULE N°1
 	if c.Type == text && c.AnyContent != "" {
-		return errors.New("If the value of 'type' is 'text', the content of " +
-			"atom:content MUST NOT contain child elements.")
+		v.error(path, "4.1.3.3", "If the value of 'type' is 'text', the "+
+			"content of atom:content MUST NOT contain child elements.")
 	}
 
 	// https://tools.ietf.org/html/rfc4287#section-4.1.3.2 RULE N°2
 	if c.Type == html && c.AnyContent != "" {
-		return errors.New("If the value of 'type' is 'html', the content of " +
-			"atom:content MUST NOT contain child elements. Any markup within " +
-			"be escaped.")
+		v.error(path, "4.1.3.3", "If the value of 'type' is 'html', the "+
+			"content of atom:content MUST NOT contain child elements. Any "+
+			"markup within be escaped.")
+	}
+
+	// https://tools.ietf.org/html/rfc4287#section-4.1.3.3 RULE N°3
+	if c.Type == xhtml && c.Src == "" && !isXHTMLDiv(c.XMLContent) {
+		v.error(path, "4.1.3.3", "If the value of 'type' is 'xhtml', the "+
+			"content of atom:content MUST be a single XHTML div element.")
 	}
 
 	// https://tools.ietf.org/html/rfc4287#section-4.1.3.2 RULE N°5
 	lowerType := strings.ToLower(c.Type)
 	if strings.HasPrefix(lowerType, "text/") && c.AnyContent != "" {
-		return errors.New("If the value of 'type' begins with 'text/'' " +
-			"(case insensitive), the content of atom:content MUST NOT " +
-			"contain child elements.")
+		v.error(path, "4.1.3.3", "If the value of 'type' begins with "+
+			"'text/'' (case insensitive), the content of atom:content MUST "+
+			"NOT contain child elements.")
+	}
+}
+
+// isMediaType returns true if the value has the type/subtype syntax of a MIME
+// media type
+// https://tools.ietf.org/html/rfc4288#section-4.2
+func isMediaType(value string) bool {
+	if index := strings.Index(value, ";"); index >= 0 {
+		value = value[:index]
 	}
 
-	// TODO : check if xhtml is valid
-	return nil
+	parts := strings.Split(strings.TrimSpace(value), "/")
+	return len(parts) == 2 && parts[0] != "" && parts[1] != "" &&
+		!strings.ContainsAny(value, " \t")
+}
+
+// isXHTMLDiv returns true if the XML content is a single XHTML div element.
+// The content is read without the namespaces declared by its ancestors, a
+// prefixed div is trusted.
+func isXHTMLDiv(content string) bool {
+	d := xml.NewDecoder(strings.NewReader(content))
+	divs := 0
+
+	for {
+		t, err := d.Token()
+		if err == io.EOF {
+			return divs == 1
+		}
+		if err != nil {
+			return false
+		}
+
+		switch tok := t.(type) {
+		case xml.StartElement:
+			if divs > 0 || tok.Name.Local != "div" ||
+				(tok.Name.Space != NamespaceXHTML &&
+					(tok.Name.Space == "" || strings.Contains(tok.Name.Space, ":"))) {
+				return false
+			}
+			divs++
+
+			if err := d.Skip(); err != nil {
+				return false
+			}
+		case xml.CharData:
+			if strings.TrimSpace(string(tok)) != "" {
+				return false
+			}
+		}
+	}
 }
 
 // https://tools.ietf.org/html/rfc4287#section-4.2.7
-func (l *Link) check() error {
+func (l *Link) check(v *validator, path string) {
 
 	// https://tools.ietf.org/html/rfc4287#section-4.2.7.1
 	if l.Href == "" {
-		return errors.New("atom:link elements MUST have an 'href' attribute")
+		v.error(path, "4.2.7.1", "atom:link elements MUST have an 'href' "+
+			"attribute")
 	}
+}
 
-	return nil
+// present returns false if the Text construct is absent, optional Text
+// constructs are checked by their parent
+func (t *Text) present() bool {
+	return t.Type != "" || t.XMLContent != ""
 }
 
 // https://tools.ietf.org/html/rfc4287#section-3.1
-func (t *Text) check() error {
+func (t *Text) check(v *validator, path string) {
+	if !t.present() {
+		return
+	}
 
 	// https://tools.ietf.org/html/rfc4287#section-3.1.1
 	if t.Type != text && t.Type != html && t.Type != xhtml {
-		return errors.New("attr:type MUST be one of 'text', 'html', or 'xhtml'")
+		v.error(path, "3.1.1", "attr:type MUST be one of 'text', 'html', or "+
+			"'xhtml'")
 	}
 
 	// https://tools.ietf.org/html/rfc4287#section-3.1.1.1
 	if t.Type == text && t.AnyContent != "" {
-		return errors.New("attr:type set to 'text': the content of the Text " +
-			"construct MUST NOT contain child elements.")
+		v.error(path, "3.1.1.1", "attr:type set to 'text': the content of "+
+			"the Text construct MUST NOT contain child elements.")
 	}
 
 	// https://tools.ietf.org/html/rfc4287#section-3.1.1.1
 	if t.Type == html && t.AnyContent != "" {
-		return errors.New("attr:type set to 'html': the content of the Text " +
-			"construct MUST NOT contain child elements. Any markup within " +
-			"be escaped.")
+		v.error(path, "3.1.1.2", "attr:type set to 'html': the content of "+
+			"the Text construct MUST NOT contain child elements. Any markup "+
+			"within be escaped.")
 	}
 
-	// TODO : check if xhtml is valid
-	return nil
+	// https://tools.ietf.org/html/rfc4287#section-3.1.1.3 the constructs
+	// built in memory have no XMLContent, they are wrapped by Marshal
+	if t.Type == xhtml && t.XMLContent != "" && !isXHTMLDiv(t.XMLContent) {
+		v.error(path, "3.1.1.3", "attr:type set to 'xhtml': the content of "+
+			"the Text construct MUST be a single XHTML div element.")
+	}
 }
 
 // https://tools.ietf.org/html/rfc4287#section-3.2
-func (p *Person) check() error {
+func (p *Person) check(v *validator, path string) {
 
 	// https://tools.ietf.org/html/rfc4287#section-3.2.1
-	if p.Name == "" {
-		return errors.New("person constructs MUST contain exactly one " +
-			"'atom:name' element.")
+	if p.Name == "" || v.positions.Count(path, "name") > 1 {
+		v.error(path+"/name", "3.2.1", "person constructs MUST contain "+
+			"exactly one 'atom:name' element.")
 	}
 
-	//TODO no more than one URI and Email Element
-	return nil
+	// https://tools.ietf.org/html/rfc4287#section-3.2.2
+	if v.positions.Count(path, "uri") > 1 {
+		v.error(path+"/uri[2]", "3.2.2", "person constructs MUST NOT contain "+
+			"more than one 'atom:uri' element.")
+	}
+
+	// https://tools.ietf.org/html/rfc4287#section-3.2.3
+	if v.positions.Count(path, "email") > 1 {
+		v.error(path+"/email[2]", "3.2.3", "person constructs MUST NOT "+
+			"contain more than one 'atom:email' element.")
+	}
 }
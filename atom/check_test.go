@@ -0,0 +1,158 @@
+// This file is part of clutch.
+//
+// clutch is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// clutch is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with Foobar.  If not, see <http://www.gnu.org/licenses/>.
+
+package atom
+
+import (
+	"io/ioutil"
+	"testing"
+
+	"github.com/racam/clutch/validation"
+)
+
+func TestValidateDocument(t *testing.T) {
+	filename := "unit_07_check_report.xml"
+	data, err := ioutil.ReadFile(prefix + filename)
+	if err != nil {
+		t.Fatalf("[Atom][Unit][ValidateDocument] file '%s' : is missing",
+			prefix+filename)
+	}
+
+	report, err := ValidateDocument(data)
+	if err != nil {
+		t.Fatalf("[Atom][Unit][ValidateDocument] file '%s' : %s", filename, err)
+	}
+
+	var expected = []struct {
+		path    string // location of the violation
+		section string // section of RFC 4287
+		line    int    // line of the element or of its parent
+		column  int    // column after the start tag
+	}{
+		{"/feed", "4.1.1", 6, 43},
+		{"/feed/category[1]", "4.2.2.1", 9, 30},
+		{"/feed/entry[2]", "4.1.2", 17, 10},
+		{"/feed/entry[2]/author[1]/name", "3.2.1", 21, 13},
+	}
+
+	if len(report.Violations) != len(expected) {
+		t.Fatalf("[Atom][Unit][ValidateDocument] expected %d violations, "+
+			"actual :\n%s", len(expected), report)
+	}
+
+	for index, e := range expected {
+		v := report.Violations[index]
+		if v.Path != e.path || v.Section != e.section || v.Line != e.line ||
+			v.Column != e.column || v.Severity != validation.SeverityError ||
+			v.Spec != "RFC 4287" {
+			t.Errorf("[Atom][Unit][ValidateDocument] violation %d : expected "+
+				"%+v, actual %+v", index, e, v)
+		}
+	}
+
+	if report.Valid() {
+		t.Errorf("[Atom][Unit][ValidateDocument] expected an invalid report")
+	}
+}
+
+func TestValidateRules(t *testing.T) {
+	var rules = []struct {
+		filename string // input file
+		path     string // expected location, empty if the file is valid
+		section  string // expected section of RFC 4287
+	}{
+		{"unit_08_check_valid.xml", "", ""},
+		{"unit_09_check_xhtml_text.xml", "/feed/subtitle", "3.1.1.3"},
+		{"unit_10_check_xhtml_content.xml", "/feed/entry[1]/content", "4.1.3.3"},
+		{"unit_11_check_person_uri.xml", "/feed/author[1]/uri[2]", "3.2.2"},
+		{"unit_12_check_person_email.xml", "/feed/author[1]/email[2]", "3.2.3"},
+		{"unit_13_check_person_name.xml", "/feed/author[1]/name", "3.2.1"},
+		{"unit_14_check_content_text.xml", "/feed/entry[1]/content", "4.1.3.3"},
+		{"unit_15_check_content_src_empty.xml", "/feed/entry[1]/content",
+			"4.1.3.2"},
+		{"unit_16_check_content_src_type.xml", "/feed/entry[1]/content",
+			"4.1.3.2"},
+		{"unit_17_check_content_type.xml", "/feed/entry[1]/content", "4.1.3.1"},
+		{"unit_18_check_feed_once.xml", "/feed/title[2]", "4.1.1"},
+		{"unit_19_check_entry_once.xml", "/feed/entry[1]/updated[2]", "4.1.2"},
+		{"unit_20_check_alternate.xml", "/feed/link[2]", "4.1.1"},
+		{"unit_21_check_entry_link.xml", "/feed/entry[1]", "4.1.2"},
+		{"unit_22_check_summary_src.xml", "/feed/entry[1]", "4.1.2"},
+		{"unit_23_check_summary_base64.xml", "/feed/entry[1]", "4.1.2"},
+		{"unit_24_check_id.xml", "/feed/id", "4.2.6"},
+		{"unit_25_check_date.xml", "/feed/entry[1]/updated", "3.3"},
+		{"unit_26_check_source_once.xml", "/feed/entry[1]/source/title[2]",
+			"4.2.11"},
+	}
+
+	for _, r := range rules {
+		data, err := ioutil.ReadFile(prefix + r.filename)
+		if err != nil {
+			t.Errorf("[Atom][Unit][ValidateDocument] file '%s' : is missing",
+				prefix+r.filename)
+			continue
+		}
+
+		report, err := ValidateDocument(data)
+		if err != nil {
+			t.Errorf("[Atom][Unit][ValidateDocument] file '%s' : %s",
+				r.filename, err)
+			continue
+		}
+
+		if r.path == "" {
+			if len(report.Violations) != 0 {
+				t.Errorf("[Atom][Unit][ValidateDocument] file '%s' : "+
+					"unexpected\n%s", r.filename, report)
+			}
+			continue
+		}
+
+		if len(report.Violations) != 1 || report.Violations[0].Path != r.path ||
+			report.Violations[0].Section != r.section {
+			t.Errorf("[Atom][Unit][ValidateDocument] file '%s' : expected a "+
+				"violation of section %s at '%s', actual\n%s", r.filename,
+				r.section, r.path, report)
+		}
+	}
+}
+
+func TestCheck(t *testing.T) {
+	f, err := Parse([]byte(`<feed xmlns="http://www.w3.org/2005/Atom">
+		<title>Feed title</title><category/></feed>`))
+	if err != nil {
+		t.Fatalf("[Atom][Unit][Check] %s", err)
+	}
+
+	report := Validate(f)
+	if len(report.Violations) != 3 {
+		t.Errorf("[Atom][Unit][Validate] expected 3 violations, actual :\n%s",
+			report)
+	}
+
+	for _, v := range report.Violations {
+		if v.Line != 0 || v.Column != 0 {
+			t.Errorf("[Atom][Unit][Validate] %s : expected no position", v.Path)
+		}
+	}
+
+	err = Check(f)
+	v, ok := err.(*validation.Violation)
+	if !ok || v.Path != "/feed" || v.Message != "atom:feed elements MUST "+
+		"contain exactly one atom:id element." {
+		t.Errorf("[Atom][Unit][Check] expected the first violation, actual %v",
+			err)
+	}
+}
@@ -0,0 +1,29 @@
+// This file is part of clutch.
+//
+// clutch is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// clutch is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with Foobar.  If not, see <http://www.gnu.org/licenses/>.
+
+package atom
+
+import (
+	"time"
+
+	"github.com/racam/clutch/date"
+)
+
+// Time returns the date of the Date construct. The date is read by
+// date.Parse, so the Atom 0.3 and the broken dates are accepted too, use
+// date.ParseRFC3339 to read it strictly.
+func (d *Date) Time() (time.Time, error) {
+	return date.Parse(d.DateTime)
+}
@@ -0,0 +1,239 @@
+// This file is part of clutch.
+//
+// clutch is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// clutch is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with Foobar.  If not, see <http://www.gnu.org/licenses/>.
+
+package atom
+
+import (
+	"encoding/xml"
+
+	"github.com/racam/clutch/dc"
+	"github.com/racam/clutch/ext"
+	"github.com/racam/clutch/internal/xmldec"
+	"github.com/racam/clutch/media"
+)
+
+// decodeAttr fills the common attributes from the ones of the element, like
+// the struct tags they match whatever their namespace
+func (c *CommonAttributes) decodeAttr(start xml.StartElement) {
+	for _, a := range start.Attr {
+		switch a.Name.Local {
+		case "base":
+			c.Base = a.Value
+		case "lang":
+			c.Lang = a.Value
+		}
+	}
+}
+
+// checkName returns the error of encoding/xml when the element is not the
+// expected one, like the XMLName struct tags did
+func checkName(start xml.StartElement, local string) error {
+	if start.Name.Local != local {
+		return xml.UnmarshalError("expected element type <" + local +
+			"> but have <" + start.Name.Local + ">")
+	}
+
+	return nil
+}
+
+// UnmarshalXML decodes the atom:feed element, see xmldec.DecodeChildren
+func (f *Feed) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
+	if err := checkName(start, "feed"); err != nil {
+		return err
+	}
+
+	f.XMLName = start.Name
+	f.CommonAttributes.decodeAttr(start)
+
+	inAtom := xmldec.InNamespace(start.Name.Space)
+	return xmldec.DecodeChildren(d, inAtom, func(name string) interface{} {
+		switch name {
+		case "author":
+			f.Author = append(f.Author, Person{})
+			return &f.Author[len(f.Author)-1]
+		case "category":
+			f.Category = append(f.Category, Category{})
+			return &f.Category[len(f.Category)-1]
+		case "contributor":
+			f.Contributor = append(f.Contributor, Person{})
+			return &f.Contributor[len(f.Contributor)-1]
+		case "entry":
+			f.Entry = append(f.Entry, Entry{})
+			return &f.Entry[len(f.Entry)-1]
+		case "generator":
+			return &f.Generator
+		case "icon":
+			return &f.Icon
+		case "id":
+			return &f.ID
+		case "link":
+			f.Link = append(f.Link, Link{})
+			return &f.Link[len(f.Link)-1]
+		case "logo":
+			return &f.Logo
+		case "rights":
+			return &f.Rights
+		case "subtitle":
+			return &f.Subtitle
+		case "title":
+			return &f.Title
+		case "updated":
+			return &f.Updated
+		}
+		return nil
+	}, f.module)
+}
+
+// module returns the value of an element of the modules of the feed, see
+// ext.Modules. The elements of the unknown namespaces are kept in the
+// extensions.
+func (f *Feed) module(name xml.Name) interface{} {
+	if v := f.Modules.Element(name, f.fields); v != nil {
+		return v
+	}
+
+	return f.Extensions.Element(name)
+}
+
+// fields returns the module of the namespace kept in a field of the feed, see
+// ext.Modules
+func (f *Feed) fields(space string) ext.Module {
+	switch space {
+	case dc.Namespace, dc.NamespaceTerms:
+		return &f.DublinCore
+	}
+
+	return nil
+}
+
+// UnmarshalXML decodes the atom:entry element, see xmldec.DecodeChildren
+func (e *Entry) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
+	if err := checkName(start, "entry"); err != nil {
+		return err
+	}
+
+	e.XMLName = start.Name
+	e.CommonAttributes.decodeAttr(start)
+
+	inAtom := xmldec.InNamespace(start.Name.Space)
+	return xmldec.DecodeChildren(d, inAtom, func(name string) interface{} {
+		switch name {
+		case "author":
+			e.Author = append(e.Author, Person{})
+			return &e.Author[len(e.Author)-1]
+		case "category":
+			e.Category = append(e.Category, Category{})
+			return &e.Category[len(e.Category)-1]
+		case "content":
+			return &e.Content
+		case "contributor":
+			e.Contributor = append(e.Contributor, Person{})
+			return &e.Contributor[len(e.Contributor)-1]
+		case "id":
+			return &e.ID
+		case "link":
+			e.Link = append(e.Link, Link{})
+			return &e.Link[len(e.Link)-1]
+		case "published":
+			return &e.Published
+		case "rights":
+			return &e.Rights
+		case "source":
+			return &e.Source
+		case "summary":
+			return &e.Summary
+		case "title":
+			return &e.Title
+		case "updated":
+			return &e.Updated
+		}
+		return nil
+	}, e.module)
+}
+
+// module returns the value of an element of the modules of the entry, see
+// ext.Modules. The elements of the unknown namespaces are kept in the
+// extensions.
+func (e *Entry) module(name xml.Name) interface{} {
+	if v := e.Modules.Element(name, e.fields); v != nil {
+		return v
+	}
+
+	return e.Extensions.Element(name)
+}
+
+// fields returns the module of the namespace kept in a field of the entry, see
+// ext.Modules
+func (e *Entry) fields(space string) ext.Module {
+	switch space {
+	case dc.Namespace, dc.NamespaceTerms:
+		return &e.DublinCore
+	case media.Namespace:
+		return &e.Media
+	}
+
+	return nil
+}
+
+// UnmarshalXML decodes the atom:source element, see xmldec.DecodeChildren
+func (s *Source) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
+	s.CommonAttributes.decodeAttr(start)
+
+	inAtom := xmldec.InNamespace(start.Name.Space)
+	return xmldec.DecodeChildren(d, inAtom, func(name string) interface{} {
+		switch name {
+		case "author":
+			s.Author = append(s.Author, Person{})
+			return &s.Author[len(s.Author)-1]
+		case "category":
+			s.Category = append(s.Category, Category{})
+			return &s.Category[len(s.Category)-1]
+		case "contributor":
+			s.Contributor = append(s.Contributor, Person{})
+			return &s.Contributor[len(s.Contributor)-1]
+		case "generator":
+			return &s.Generator
+		case "icon":
+			return &s.Icon
+		case "id":
+			return &s.ID
+		case "link":
+			s.Link = append(s.Link, Link{})
+			return &s.Link[len(s.Link)-1]
+		case "logo":
+			return &s.Logo
+		case "rights":
+			return &s.Rights
+		case "subtitle":
+			return &s.Subtitle
+		case "title":
+			return &s.Title
+		case "updated":
+			return &s.Updated
+		}
+		return nil
+	}, s.module)
+}
+
+// module returns the value of an element of the modules of the source, see
+// ext.Modules. The elements of the unknown namespaces are kept in the
+// extensions.
+func (s *Source) module(name xml.Name) interface{} {
+	if v := s.Modules.Element(name, nil); v != nil {
+		return v
+	}
+
+	return s.Extensions.Element(name)
+}
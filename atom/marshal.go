@@ -0,0 +1,436 @@
+// This file is part of clutch.
+//
+// clutch is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// clutch is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with Foobar.  If not, see <http://www.gnu.org/licenses/>.
+
+package atom
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/xml"
+	"io"
+	"reflect"
+	"strings"
+
+	"github.com/racam/clutch/date"
+	"github.com/racam/clutch/dc"
+	"github.com/racam/clutch/internal/xmlenc"
+	"github.com/racam/clutch/media"
+)
+
+// NamespaceXHTML is the namespace of the div element which wraps the xhtml
+// Text constructs
+const NamespaceXHTML string = "http://www.w3.org/1999/xhtml"
+
+// Marshal returns the Atom 1.0 document of the feed. See Write.
+func Marshal(f *Feed) ([]byte, error) {
+	var buf bytes.Buffer
+
+	if err := Write(&buf, f); err != nil {
+		return nil, err
+	}
+
+	return buf.Bytes(), nil
+}
+
+// prefixes are the usual prefixes of the modules, declared on the root element
+// for the ones the document uses
+var prefixes = []xmlenc.Prefix{
+	{Name: "dc", Space: dc.Namespace},
+	{Name: "dcterms", Space: dc.NamespaceTerms},
+	{Name: "media", Space: media.Namespace},
+}
+
+// Write writes the Atom 1.0 document of the feed to w. A feed that is not
+// declared and holds a single entry is written as an Atom Entry Document.
+// The fields filled by the parsing (Content, Type, ...) are used, the raw
+// TextContent and XMLContent fields are ignored. Atom 0.3 feeds are written
+// as Atom 1.0 documents. The elements of the modules are written with their
+// usual prefix, declared on the root element.
+func Write(w io.Writer, f *Feed) error {
+	enc := xmlenc.NewEncoder(w)
+
+	write := f.write
+	if !f.IsDeclared && len(f.Entry) == 1 {
+		write = func(enc *xmlenc.Encoder) {
+			f.Entry[0].write(enc, true)
+		}
+	}
+
+	enc.Bind(write, prefixes...)
+	write(enc)
+
+	return enc.Close()
+}
+
+// https://tools.ietf.org/html/rfc4287#section-4.1.1
+func (f *Feed) write(enc *xmlenc.Encoder) {
+	n := xml.Name{Space: Namespace10, Local: "feed"}
+	enc.Start(n, f.CommonAttributes.attrs()...)
+
+	writeURI(enc, "id", CommonURI(f.ID))
+	f.Title.write(enc, "title")
+	f.Subtitle.write(enc, "subtitle")
+	f.Updated.write(enc, "updated")
+	writePersons(enc, "author", f.Author)
+	writePersons(enc, "contributor", f.Contributor)
+
+	for index := range f.Category {
+		f.Category[index].write(enc)
+	}
+
+	for index := range f.Link {
+		f.Link[index].write(enc)
+	}
+
+	f.Generator.write(enc)
+	writeURI(enc, "icon", CommonURI(f.Icon))
+	writeURI(enc, "logo", CommonURI(f.Logo))
+	f.Rights.write(enc, "rights")
+	f.DublinCore.Write(enc)
+	f.Modules.Write(enc)
+	f.Extensions.Write(enc)
+
+	for index := range f.Entry {
+		f.Entry[index].write(enc, false)
+	}
+
+	enc.End(n)
+}
+
+// https://tools.ietf.org/html/rfc4287#section-4.1.2
+func (e *Entry) write(enc *xmlenc.Encoder, root bool) {
+	n := xml.Name{Local: "entry"}
+	if root {
+		n.Space = Namespace10
+	}
+	enc.Start(n, e.CommonAttributes.attrs()...)
+
+	writeURI(enc, "id", CommonURI(e.ID))
+	e.Title.write(enc, "title")
+	e.Updated.write(enc, "updated")
+	e.Published.write(enc, "published")
+	writePersons(enc, "author", e.Author)
+	writePersons(enc, "contributor", e.Contributor)
+
+	for index := range e.Category {
+		e.Category[index].write(enc)
+	}
+
+	for index := range e.Link {
+		e.Link[index].write(enc)
+	}
+
+	e.Rights.write(enc, "rights")
+	e.Source.write(enc)
+	e.Summary.write(enc, "summary")
+	e.Content.write(enc)
+	e.DublinCore.Write(enc)
+	e.Media.Write(enc)
+	e.Modules.Write(enc)
+	e.Extensions.Write(enc)
+
+	enc.End(n)
+}
+
+// https://tools.ietf.org/html/rfc4287#section-4.2.11
+func (s *Source) write(enc *xmlenc.Encoder) {
+	if reflect.DeepEqual(*s, Source{}) {
+		return
+	}
+
+	n := xml.Name{Local: "source"}
+	enc.Start(n, s.CommonAttributes.attrs()...)
+
+	writeURI(enc, "id", CommonURI(s.ID))
+	s.Title.write(enc, "title")
+	s.Subtitle.write(enc, "subtitle")
+	s.Updated.write(enc, "updated")
+	writePersons(enc, "author", s.Author)
+	writePersons(enc, "contributor", s.Contributor)
+
+	for index := range s.Category {
+		s.Category[index].write(enc)
+	}
+
+	for index := range s.Link {
+		s.Link[index].write(enc)
+	}
+
+	s.Generator.write(enc)
+	writeURI(enc, "icon", CommonURI(s.Icon))
+	writeURI(enc, "logo", CommonURI(s.Logo))
+	s.Rights.write(enc, "rights")
+	s.Modules.Write(enc)
+	s.Extensions.Write(enc)
+
+	enc.End(n)
+}
+
+// https://tools.ietf.org/html/rfc4287#section-4.1.3
+func (c *Content) write(enc *xmlenc.Encoder) {
+	if c.Type == "" && c.Src == "" && c.Content == "" &&
+		c.CommonAttributes == (CommonAttributes{}) {
+		return
+	}
+
+	n := xml.Name{Local: "content"}
+	attrs := append(c.CommonAttributes.attrs(),
+		xmlenc.Attr("type", c.Type), xmlenc.Attr("src", c.Src))
+	enc.Start(n, attrs...)
+
+	// https://tools.ietf.org/html/rfc4287#section-4.1.3.3
+	switch {
+	case c.Src != "":
+		// If the "src" attribute is present, atom:content MUST be empty
+	case c.Type == xhtml:
+		writeXHTML(enc, c.Content)
+	case isXMLMediaType(c.Type) && wellFormed(c.Content):
+		enc.Raw(c.Content)
+	case isXMLMediaType(c.Type) &&
+		!strings.HasPrefix(strings.ToLower(c.Type), "text/"):
+		// A content which is not XML is written like the other non-text
+		// media types
+		enc.Text(base64.StdEncoding.EncodeToString([]byte(c.Content)))
+	default:
+		enc.Text(c.Content)
+	}
+
+	enc.End(n)
+}
+
+// https://tools.ietf.org/html/rfc4287#section-3.1
+func (t *Text) write(enc *xmlenc.Encoder, name string) {
+	if t.Type == "" && t.Content == "" &&
+		t.CommonAttributes == (CommonAttributes{}) {
+		return
+	}
+
+	n := xml.Name{Local: name}
+	attrs := append(t.CommonAttributes.attrs(), xmlenc.Attr("type", t.Type))
+	enc.Start(n, attrs...)
+
+	// https://tools.ietf.org/html/rfc4287#section-3.1.1.3
+	if t.Type == xhtml {
+		writeXHTML(enc, t.Content)
+	} else {
+		enc.Text(t.Content)
+	}
+
+	enc.End(n)
+}
+
+// https://tools.ietf.org/html/rfc4287#section-3.2
+func writePersons(enc *xmlenc.Encoder, name string, persons []Person) {
+	for _, p := range persons {
+		n := xml.Name{Local: name}
+		enc.Start(n, p.CommonAttributes.attrs()...)
+		enc.Element("name", p.Name)
+		enc.Element("uri", p.URI)
+		enc.Element("email", p.Email)
+		enc.End(n)
+	}
+}
+
+// https://tools.ietf.org/html/rfc4287#section-3.3
+func (d *Date) write(enc *xmlenc.Encoder, name string) {
+	if d.DateTime == "" && d.CommonAttributes == (CommonAttributes{}) {
+		return
+	}
+
+	n := xml.Name{Local: name}
+	enc.Start(n, d.CommonAttributes.attrs()...)
+	enc.Text(date.RFC3339(d.DateTime))
+	enc.End(n)
+}
+
+// https://tools.ietf.org/html/rfc4287#section-4.2.2
+func (c *Category) write(enc *xmlenc.Encoder) {
+	n := xml.Name{Local: "category"}
+	attrs := append(c.CommonAttributes.attrs(), xmlenc.Attr("term", c.Term),
+		xmlenc.Attr("scheme", c.Scheme), xmlenc.Attr("label", c.Label))
+	enc.Start(n, attrs...)
+	writeUndefinedContent(enc, c.Content, c.TextContent, c.XMLContent)
+	enc.End(n)
+}
+
+// https://tools.ietf.org/html/rfc4287#section-4.2.4
+func (g *Generator) write(enc *xmlenc.Encoder) {
+	if *g == (Generator{}) {
+		return
+	}
+
+	n := xml.Name{Local: "generator"}
+	attrs := append(g.CommonAttributes.attrs(), xmlenc.Attr("uri", g.URI),
+		xmlenc.Attr("version", g.Version))
+	enc.Start(n, attrs...)
+	enc.Text(g.Content)
+	enc.End(n)
+}
+
+// https://tools.ietf.org/html/rfc4287#section-4.2.7
+func (l *Link) write(enc *xmlenc.Encoder) {
+	n := xml.Name{Local: "link"}
+	attrs := append(l.CommonAttributes.attrs(), xmlenc.Attr("href", l.Href),
+		xmlenc.Attr("rel", l.Rel), xmlenc.Attr("type", l.Type),
+		xmlenc.Attr("hreflang", l.Hreflang), xmlenc.Attr("title", l.Title),
+		xmlenc.Attr("length", l.Length))
+	enc.Start(n, attrs...)
+	writeUndefinedContent(enc, l.Content, l.TextContent, l.XMLContent)
+	enc.End(n)
+}
+
+// writeURI writes atom:id, atom:icon and atom:logo
+func writeURI(enc *xmlenc.Encoder, name string, u CommonURI) {
+	if u == (CommonURI{}) {
+		return
+	}
+
+	n := xml.Name{Local: name}
+	enc.Start(n, u.CommonAttributes.attrs()...)
+	enc.Text(u.URI)
+	enc.End(n)
+}
+
+// writeUndefinedContent writes the content of atom:link and atom:category,
+// the child elements found by the parsing are written back as they were
+func writeUndefinedContent(enc *xmlenc.Encoder, content string,
+	textContent string, xmlContent string) {
+	if textContent == "" && xmlContent != "" && content == xmlContent &&
+		wellFormed(content) {
+		enc.Raw(content)
+	} else {
+		enc.Text(content)
+	}
+}
+
+func (c CommonAttributes) attrs() []xml.Attr {
+	return []xml.Attr{
+		xmlenc.XMLAttr("base", c.Base),
+		xmlenc.XMLAttr("lang", c.Lang),
+	}
+}
+
+// writeXHTML writes the xhtml content in a single xhtml div element, see
+// xhtmlDiv. A content which is not well-formed XML is written as the text of
+// the div, so it does not break the document.
+func writeXHTML(enc *xmlenc.Encoder, content string) {
+	if wellFormed(content) {
+		enc.Raw(xhtmlDiv(content))
+		return
+	}
+
+	n := xml.Name{Space: NamespaceXHTML, Local: "div"}
+	enc.Start(n)
+	enc.Text(content)
+	enc.End(n)
+}
+
+// wellFormed returns true if the content can be written as it is inside an
+// element : its elements are balanced and it has neither an XML declaration
+// nor a document type declaration
+func wellFormed(content string) bool {
+	d := xml.NewDecoder(strings.NewReader(content))
+	for {
+		t, err := d.Token()
+		if err == io.EOF {
+			return true
+		} else if err != nil {
+			return false
+		}
+
+		switch tok := t.(type) {
+		case xml.Directive:
+			return false
+		case xml.ProcInst:
+			if strings.EqualFold(tok.Target, "xml") {
+				return false
+			}
+		}
+	}
+}
+
+// xhtmlDiv returns the xhtml content wrapped in a single xhtml div element,
+// the existing div is kept and declares the xhtml namespace if it does not.
+// Any other content, including a prefixed div, is wrapped.
+// source : https://tools.ietf.org/html/rfc4287#section-3.1.1.3
+func xhtmlDiv(content string) string {
+	trimmed := strings.TrimSpace(content)
+
+	d := xml.NewDecoder(strings.NewReader(trimmed))
+	depth, roots := 0, 0
+	var root xml.Name
+
+	for {
+		t, err := d.Token()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			roots = 0
+			break
+		}
+
+		switch tok := t.(type) {
+		case xml.StartElement:
+			if depth == 0 {
+				roots++
+				root = tok.Name
+			}
+			depth++
+		case xml.EndElement:
+			depth--
+		case xml.CharData:
+			if depth == 0 && len(bytes.TrimSpace(tok)) > 0 {
+				roots = 0
+			}
+		}
+
+		if roots > 1 {
+			break
+		}
+	}
+
+	if roots == 1 && root.Space == NamespaceXHTML && root.Local == "div" {
+		return trimmed
+	}
+
+	// Declare the namespace on the existing div
+	if roots == 1 && root.Space == "" && root.Local == "div" {
+		return `<div xmlns="` + NamespaceXHTML + `"` + trimmed[len("<div"):]
+	}
+
+	// A prefix declared on an ancestor of the content, e.g. xhtml:div, is left
+	// unbound by the decoder : it is declared on the wrapping div, with the
+	// xhtml namespace of the content
+	wrapper := `<div xmlns="` + NamespaceXHTML + `"`
+	if roots == 1 && root.Space != "" &&
+		strings.HasPrefix(trimmed, "<"+root.Space+":") {
+		wrapper += ` xmlns:` + root.Space + `="` + NamespaceXHTML + `"`
+	}
+
+	return wrapper + `>` + content + `</div>`
+}
+
+// isXMLMediaType returns true if the content of the type is inline XML
+// source : https://tools.ietf.org/html/rfc4287#section-4.1.3.3
+func isXMLMediaType(mediaType string) bool {
+	mediaType = strings.ToLower(mediaType)
+	if index := strings.Index(mediaType, ";"); index >= 0 {
+		mediaType = strings.TrimSpace(mediaType[:index])
+	}
+
+	return strings.HasSuffix(mediaType, "+xml") ||
+		strings.HasSuffix(mediaType, "/xml")
+}
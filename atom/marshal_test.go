@@ -0,0 +1,238 @@
+// This file is part of clutch.
+//
+// clutch is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// clutch is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with Foobar.  If not, see <http://www.gnu.org/licenses/>.
+
+package atom
+
+import (
+	"encoding/xml"
+	"io/ioutil"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// TestMarshalRoundTrip parses the integration fixtures, writes them and
+// parses the result again. Both feeds MUST be equivalent once the raw fields,
+// which depend on the serialization, are ignored.
+func TestMarshalRoundTrip(t *testing.T) {
+	files, _ := filepath.Glob("../testdata/atom/integ_*.xml")
+	for _, file := range files {
+		data, err := ioutil.ReadFile(file)
+		if err != nil {
+			t.Errorf("[Atom][Marshal] %s", err)
+			continue
+		}
+
+		expected, err := Parse(data)
+		if err != nil {
+			t.Errorf("[Atom][Marshal] file %s impossible to parse : %s", file, err)
+			continue
+		}
+
+		out, err := Marshal(expected)
+		if err != nil {
+			t.Errorf("[Atom][Marshal] file %s impossible to marshal : %s",
+				file, err)
+			continue
+		}
+
+		actual, err := Parse(out)
+		if err != nil {
+			t.Errorf("[Atom][Marshal] file %s, output impossible to parse : %s",
+				file, err)
+			t.Logf("[DEBUG]%s", out)
+			continue
+		}
+
+		normalizeFeed(expected)
+		normalizeFeed(actual)
+		if !reflect.DeepEqual(actual, expected) {
+			t.Errorf("[Atom][Marshal] file %s, documents are not equivalent",
+				file)
+			t.Logf("[DEBUG]%s", out)
+			t.Logf("[DEBUG]%+v", actual)
+			t.Logf("[DEBUG]%+v", expected)
+		}
+	}
+}
+
+func TestMarshalText(t *testing.T) {
+	f := Feed{IsDeclared: true}
+	f.Title = Text{Content: "<b>Title</b>", Type: "html"}
+	f.Subtitle = Text{Content: "<p>Subtitle</p>", Type: "xhtml"}
+	f.Rights = Text{Content: "Rights & co", Type: "text"}
+	f.Updated.DateTime = "Mon, 02 Jan 2006 15:04:05 -0700"
+
+	out, err := Marshal(&f)
+	if err != nil {
+		t.Fatalf("[Atom][Marshal] %s", err)
+	}
+
+	var expected = []string{
+		`<feed xmlns="http://www.w3.org/2005/Atom">`,
+		`<title type="html">&lt;b&gt;Title&lt;/b&gt;</title>`,
+		`<subtitle type="xhtml"><div xmlns="http://www.w3.org/1999/xhtml">` +
+			`<p>Subtitle</p></div></subtitle>`,
+		`<rights type="text">Rights &amp; co</rights>`,
+		`<updated>2006-01-02T15:04:05-07:00</updated>`,
+	}
+
+	for _, e := range expected {
+		if !strings.Contains(string(out), e) {
+			t.Errorf("[Atom][Marshal] expected '%s' in\n%s", e, out)
+		}
+	}
+}
+
+func TestMarshalModules(t *testing.T) {
+	f := Feed{Entry: []Entry{{}}}
+	f.Entry[0].DublinCore.Creator = []string{"John Doe"}
+	f.Entry[0].Media.Keywords = []string{"go"}
+
+	out, err := Marshal(&f)
+	if err != nil {
+		t.Fatalf("[Atom][Marshal] %s", err)
+	}
+
+	var expected = []string{
+		`<entry xmlns="http://www.w3.org/2005/Atom" ` +
+			`xmlns:dc="http://purl.org/dc/elements/1.1/" ` +
+			`xmlns:media="http://search.yahoo.com/mrss/">`,
+		`<dc:creator>John Doe</dc:creator>`,
+		`<media:keywords>go</media:keywords>`,
+	}
+
+	for _, e := range expected {
+		if !strings.Contains(string(out), e) {
+			t.Errorf("[Atom][Marshal] expected '%s' in\n%s", e, out)
+		}
+	}
+}
+
+func TestMarshalMalformed(t *testing.T) {
+	f := Feed{IsDeclared: true}
+	f.Subtitle = Text{Content: "<p>a</div>", Type: "xhtml"}
+	f.Entry = []Entry{
+		{Content: Content{Text: Text{Content: "</content><evil/>"},
+			Type: "application/xml"}},
+		{Content: Content{Text: Text{Content: "<a>b"}, Type: "text/xml"}},
+		{Content: Content{Text: Text{Content: `<?xml version="1.0"?><a/>`},
+			Type: "image/svg+xml"}},
+	}
+
+	out, err := Marshal(&f)
+	if err != nil {
+		t.Fatalf("[Atom][Marshal] %s", err)
+	}
+
+	if !wellFormed(string(out[len(xml.Header):])) {
+		t.Errorf("[Atom][Marshal] malformed contents break the document\n%s",
+			out)
+	}
+
+	var expected = []string{
+		`<div xmlns="http://www.w3.org/1999/xhtml">&lt;p&gt;a&lt;/div&gt;</div>`,
+		`<content type="application/xml">PC9jb250ZW50PjxldmlsLz4=</content>`,
+		`<content type="text/xml">&lt;a&gt;b</content>`,
+		`<content type="image/svg+xml">` +
+			`PD94bWwgdmVyc2lvbj0iMS4wIj8+PGEvPg==</content>`,
+	}
+
+	for _, e := range expected {
+		if !strings.Contains(string(out), e) {
+			t.Errorf("[Atom][Marshal] expected '%s' in\n%s", e, out)
+		}
+	}
+}
+
+func TestXHTMLDiv(t *testing.T) {
+	var contents = []struct {
+		content  string // input content
+		expected string // expected result
+	}{
+		{`<div xmlns="http://www.w3.org/1999/xhtml">a</div>`,
+			`<div xmlns="http://www.w3.org/1999/xhtml">a</div>`},
+		{`<div>a</div>`, `<div xmlns="http://www.w3.org/1999/xhtml">a</div>`},
+		{`<div>a</div><p>b</p>`,
+			`<div xmlns="http://www.w3.org/1999/xhtml"><div>a</div><p>b</p></div>`},
+		{`text`, `<div xmlns="http://www.w3.org/1999/xhtml">text</div>`},
+		{`<divider>x</divider>`,
+			`<div xmlns="http://www.w3.org/1999/xhtml"><divider>x</divider></div>`},
+		{`<xhtml:div>a</xhtml:div>`,
+			`<div xmlns="http://www.w3.org/1999/xhtml" ` +
+				`xmlns:xhtml="http://www.w3.org/1999/xhtml">` +
+				`<xhtml:div>a</xhtml:div></div>`},
+		{`<x:div xmlns:x="http://www.w3.org/1999/xhtml">a</x:div>`,
+			`<x:div xmlns:x="http://www.w3.org/1999/xhtml">a</x:div>`},
+	}
+
+	for _, c := range contents {
+		if res := xhtmlDiv(c.content); res != c.expected {
+			t.Errorf("[Atom][Marshal] xhtmlDiv '%s' : expected '%s', actual '%s'",
+				c.content, c.expected, res)
+		}
+	}
+}
+
+// normalizeFeed removes the fields which depend on the serialization
+func normalizeFeed(f *Feed) {
+	f.XMLName = xml.Name{}
+	normalizeCategories(f.Category)
+	normalizeLinks(f.Link)
+	normalizeText(&f.Rights)
+	normalizeText(&f.Subtitle)
+	normalizeText(&f.Title)
+
+	for index := range f.Entry {
+		e := &f.Entry[index]
+		e.XMLName = xml.Name{}
+		normalizeCategories(e.Category)
+		normalizeLinks(e.Link)
+		normalizeText(&e.Content.Text)
+		normalizeText(&e.Rights)
+		normalizeText(&e.Summary)
+		normalizeText(&e.Title)
+		normalizeCategories(e.Source.Category)
+		normalizeLinks(e.Source.Link)
+		normalizeText(&e.Source.Rights)
+		normalizeText(&e.Source.Subtitle)
+		normalizeText(&e.Source.Title)
+	}
+}
+
+func normalizeText(t *Text) {
+	if t.Type == xhtml {
+		t.Content = strings.TrimPrefix(t.Content,
+			`<div xmlns="http://www.w3.org/1999/xhtml">`)
+		t.Content = strings.TrimSuffix(t.Content, `</div>`)
+	}
+
+	t.TextContent, t.XMLContent, t.AnyContent = "", "", ""
+}
+
+func normalizeCategories(categories []Category) {
+	for index := range categories {
+		categories[index].TextContent = ""
+		categories[index].XMLContent = ""
+	}
+}
+
+func normalizeLinks(links []Link) {
+	for index := range links {
+		links[index].TextContent = ""
+		links[index].XMLContent = ""
+	}
+}
@@ -16,20 +16,18 @@
 package atom
 
 import (
+	"bytes"
 	"encoding/xml"
-)
+	"io"
 
-// atomFeed is a simple struct to test if the xml seems to be an atom document
-// that begin with an atom:feed element
-type atomFeed struct {
-	XMLName xml.Name `xml:"http://www.w3.org/2005/Atom feed"`
-}
+	"github.com/racam/clutch/internal/xmldec"
+)
 
-// atomEntry is a simple struct to test if the xml seems to be an atom document
-// that begin with an atom:entry element
-type atomEntry struct {
-	XMLName xml.Name `xml:"http://www.w3.org/2005/Atom entry"`
-}
+// Namespaces of the root element, one for each version of Atom
+const (
+	Namespace03 string = "http://purl.org/atom/ns#"
+	Namespace10 string = "http://www.w3.org/2005/Atom"
+)
 
 const text string = "text"
 const html string = "html"
@@ -38,53 +36,110 @@ const xhtml string = "xhtml"
 // IsDeclared tries to find a atom:feed or atom:Entry element at the root of the
 // XML document and looks at the namespace of this element
 func IsDeclared(data []byte) bool {
+	root, err := rootElement(data)
+	if err != nil {
+		return false
+	}
 
-	//Test atom:feed at first because it is the most common
-	f := atomFeed{}
-	err := xml.Unmarshal(data, &f)
-
-	if err == nil {
-		return true
+	if root.Space != Namespace10 && root.Space != Namespace03 {
+		return false
 	}
 
-	e := atomEntry{}
-	err = xml.Unmarshal(data, &e)
+	return root.Local == "feed" || root.Local == "entry"
+}
 
-	return err == nil
+// Parse parses the ATOM-encoded data into an atom.Feed struct and return it,
+// see ParseReader
+func Parse(data []byte) (*Feed, error) {
+	return ParseReader(bytes.NewReader(data))
 }
 
-// Parse parses the ATOM-encoded data into an atom.Feed struct and return it.
-// The ATOM parsing do 2 steps :
-// * Check that the document is well declared as an ATOM document
+// ParseReader parses the ATOM document read from r into an atom.Feed struct
+// and return it. The document is decoded in a single pass, without being
+// buffered. The ATOM parsing do 2 steps :
+// * Decode the atom:feed or atom:entry root element
 // * Parse the structure to fill additionnals fields
-func Parse(data []byte) (*Feed, error) {
-	// Test atom:feed at first because it is the most common
+// Atom 0.3 documents are recognized by their namespace and converted into the
+// Atom 1.0 structures, the Version field tells which version was parsed.
+func ParseReader(r io.Reader) (*Feed, error) {
+	d := xmldec.NewDecoder(r)
+
+	start, err := xmldec.RootElement(d)
+	if err != nil {
+		return nil, err
+	}
+
+	return ParseElement(d, start)
+}
+
+// ParseElement parses the ATOM document of the decoder, whose root element
+// start has just been read. It lets a caller which has read the root element
+// to detect the format continue the decoding.
+func ParseElement(d *xml.Decoder, start xml.StartElement) (*Feed, error) {
+	if start.Name.Space == Namespace03 {
+		return parse03(d, start)
+	}
+
 	f := Feed{}
-	err := xml.Unmarshal(data, &f)
 
-	// If the ATOM document does not start with atom:feed element, we test with
-	// atom:entry element
-	feedIsAbsent := err != nil
-	if feedIsAbsent {
+	// A document which does not start with atom:feed element is an Atom
+	// Entry Document
+	if start.Name.Local == "entry" {
 		e := Entry{}
-		err = xml.Unmarshal(data, &e)
-
-		if err != nil {
+		if err := d.DecodeElement(&e, &start); err != nil {
 			return nil, err
 		}
 
 		f.Entry = append(f.Entry, e)
 		f.IsDeclared = false
-
 	} else {
+		if err := d.DecodeElement(&f, &start); err != nil {
+			return nil, err
+		}
+
 		f.IsDeclared = true
 	}
 
 	//Parse the structure in order to fill additionnals fields
+	f.Version = "1.0"
 	f.parseContent()
 	return &f, nil
 }
 
+// parse03 parses an Atom 0.3 document and converts it into an atom.Feed
+func parse03(d *xml.Decoder, start xml.StartElement) (*Feed, error) {
+	var f Feed
+
+	if start.Name.Local == "entry" {
+		e := entry03{}
+		if err := d.DecodeElement(&e, &start); err != nil {
+			return nil, err
+		}
+
+		f.Entry = append(f.Entry, e.toEntry())
+		f.IsDeclared = false
+	} else {
+		f03 := feed03{}
+		if err := d.DecodeElement(&f03, &start); err != nil {
+			return nil, err
+		}
+
+		f = f03.toFeed()
+		f.XMLName = start.Name
+		f.IsDeclared = true
+	}
+
+	f.Version = "0.3"
+	f.parseContent()
+	return &f, nil
+}
+
+// rootElement returns the name of the root element of the XML document
+func rootElement(data []byte) (xml.Name, error) {
+	start, err := xmldec.RootElement(xmldec.NewDecoder(bytes.NewReader(data)))
+	return start.Name, err
+}
+
 func (f *Feed) parseContent() {
 	for index := range f.Category {
 		f.Category[index].parseContent()
@@ -17,6 +17,7 @@ package atom
 
 import (
 	"io/ioutil"
+	"strings"
 	"testing"
 )
 
@@ -30,6 +31,7 @@ func TestIsDeclared(t *testing.T) {
 		{"unit_01_IsDeclared.xml", true},
 		{"unit_02_IsDeclared.xml", true},
 		{"unit_03_IsDeclared.xml", false},
+		{"unit_05_IsDeclared.xml", true},
 	}
 
 	for _, ns := range namespaces {
@@ -73,6 +75,108 @@ func TestFailParse(t *testing.T) {
 	}
 }
 
+func TestParseDublinCore(t *testing.T) {
+	filename := "unit_29_parse_dublincore.xml"
+	data, err := ioutil.ReadFile(prefix + filename)
+	if err != nil {
+		t.Fatalf("[Atom][Unit][Parse] file '%s' : is missing", prefix+filename)
+	}
+
+	f, err := Parse(data)
+	if err != nil {
+		t.Fatalf("[Atom][Unit][Parse] file '%s' : %s", filename, err)
+	}
+
+	if f.Title.Content != "Feed title" || f.Lang != "en" ||
+		len(f.DublinCore.Title) != 1 || len(f.DublinCore.Creator) != 1 {
+		t.Errorf("[Atom][Unit][Parse] Feed : unexpected title '%s', lang "+
+			"'%s' or %+v", f.Title.Content, f.Lang, f.DublinCore)
+	}
+
+	if len(f.Entry) != 1 {
+		t.Fatalf("[Atom][Unit][Parse] Entry : expected 1 entry, actual %d",
+			len(f.Entry))
+	}
+
+	e := f.Entry[0]
+	if e.Rights.Content != "Atom rights" || e.Base != "http://example.org/" ||
+		len(e.Link) != 1 {
+		t.Errorf("[Atom][Unit][Parse] Entry : unexpected rights '%s', base "+
+			"'%s' or links %+v", e.Rights.Content, e.Base, e.Link)
+	}
+
+	if len(e.DublinCore.Rights) != 1 || len(e.DublinCore.Date) != 1 ||
+		e.DublinCore.Subject[0] != "go" {
+		t.Errorf("[Atom][Unit][Parse] Entry.DublinCore : unexpected %+v",
+			e.DublinCore)
+	}
+}
+
+func TestParse03(t *testing.T) {
+	filename := "unit_06_atom03.xml"
+	data, err := ioutil.ReadFile(prefix + filename)
+
+	if err != nil {
+		t.Fatalf("[Atom][Unit][Parse] file '%s' : is missing", prefix+filename)
+	}
+
+	f, err := Parse(data)
+	if err != nil {
+		t.Fatalf("[Atom][Unit][Parse] file '%s' : %s", filename, err)
+	}
+
+	if f.Version != "0.3" || !f.IsDeclared {
+		t.Errorf("[Atom][Unit][Parse] Version : expected '0.3', actual '%s'",
+			f.Version)
+	}
+
+	if f.Subtitle.Type != "html" || f.Subtitle.Content != "<b>Tagline</b>" {
+		t.Errorf("[Atom][Unit][Parse] tagline : unexpected %+v", f.Subtitle)
+	}
+
+	if f.Rights.Content != "Copyright" || f.Updated.DateTime == "" {
+		t.Errorf("[Atom][Unit][Parse] copyright/modified : unexpected %+v %+v",
+			f.Rights, f.Updated)
+	}
+
+	if f.Generator.URI != "http://example.org/generator" {
+		t.Errorf("[Atom][Unit][Parse] generator : unexpected %+v", f.Generator)
+	}
+
+	if len(f.Author) != 1 || f.Author[0].URI != "http://example.org/john" {
+		t.Errorf("[Atom][Unit][Parse] author : unexpected %+v", f.Author)
+	}
+
+	if len(f.Entry) != 2 {
+		t.Fatalf("[Atom][Unit][Parse] entry : expected 2 entries, actual %d",
+			len(f.Entry))
+	}
+
+	e := f.Entry[0]
+	if e.Published.DateTime != "2004-01-02T03:04:05+01:00" {
+		t.Errorf("[Atom][Unit][Parse] issued : unexpected %+v", e.Published)
+	}
+
+	if e.Content.Type != "xhtml" ||
+		!strings.Contains(e.Content.Content, "Hello</div>") {
+		t.Errorf("[Atom][Unit][Parse] xml content : unexpected %+v", e.Content)
+	}
+
+	e = f.Entry[1]
+	if e.Published.DateTime != "2004-01-01T03:04:05Z" {
+		t.Errorf("[Atom][Unit][Parse] created : unexpected %+v", e.Published)
+	}
+
+	if e.Content.Type != "html" || e.Content.Content != "<p>Hello</p>" {
+		t.Errorf("[Atom][Unit][Parse] base64 content : unexpected %+v",
+			e.Content)
+	}
+
+	if err := Check(f); err != nil {
+		t.Errorf("[Atom][Unit][Check] file '%s' : %s", filename, err)
+	}
+}
+
 func TestTextParseContent(t *testing.T) {
 	var text Text
 	text.XMLContent = "xml content"
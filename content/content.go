@@ -0,0 +1,57 @@
+// This file is part of clutch.
+//
+// clutch is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// clutch is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with Foobar.  If not, see <http://www.gnu.org/licenses/>.
+// Package content please Refer to https://web.resource.org/rss/1.0/modules/content/
+package content
+
+import (
+	"encoding/xml"
+
+	"github.com/racam/clutch/ext"
+	"github.com/racam/clutch/internal/xmlenc"
+)
+
+// Namespace is the namespace of the content module
+// source : https://web.resource.org/rss/1.0/modules/content/#namespaces
+const Namespace string = "http://purl.org/rss/1.0/modules/content/"
+
+func init() {
+	ext.RegisterBuiltin(Namespace, func() ext.Module { return new(Encoded) })
+}
+
+// Encoded is the content:encoded element, the full content of an item as
+// escaped HTML
+type Encoded string
+
+// Element returns the value into which the element must be decoded, nil if it
+// is not the content:encoded element
+func (e *Encoded) Element(name xml.Name) interface{} {
+	if name == (xml.Name{Space: Namespace, Local: "encoded"}) {
+		return (*string)(e)
+	}
+
+	return nil
+}
+
+// Write writes the content:encoded element, omitted when it is empty
+func (e *Encoded) Write(enc *xmlenc.Encoder) {
+	if *e == "" {
+		return
+	}
+
+	name := xml.Name{Space: Namespace, Local: "encoded"}
+	enc.Start(name)
+	enc.Text(string(*e))
+	enc.End(name)
+}
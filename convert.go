@@ -0,0 +1,527 @@
+// This file is part of clutch.
+//
+// clutch is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// clutch is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with Foobar.  If not, see <http://www.gnu.org/licenses/>.
+
+package clutch
+
+import (
+	"crypto/sha1"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"html"
+	"net/url"
+	"strconv"
+	"time"
+
+	"github.com/racam/clutch/atom"
+	"github.com/racam/clutch/date"
+	"github.com/racam/clutch/jsonfeed"
+	"github.com/racam/clutch/rss"
+)
+
+// convertVersions are the versions of the documents built by Convert, as set
+// in Feed.Version
+var convertVersions = map[FeedType]string{
+	FeedTypeAtom: "1.0",
+	FeedTypeJSON: "1.1",
+	FeedTypeRSS:  rss.Version20,
+}
+
+// Convert builds a document of the given type from the unified model of the
+// feed and returns it as a new Feed, ready to be written with rss.Marshal,
+// atom.Marshal or encoding/json. The supported types are FeedTypeRSS (2.0),
+// FeedTypeAtom (1.0) and FeedTypeJSON (1.1). A feed which already has the
+// requested type and version is returned as it is, a feed in another version
+// of the type, e.g. RSS 0.91, is converted like the other ones.
+//
+// The conversion only knows the fields of the unified model, so some
+// information is lost or approximated :
+// * RSS guid isPermaLink and Atom id : the guid becomes the id, an Atom id
+// which is not an absolute IRI becomes a name-based urn:uuid. An id becomes
+// a permalink guid only if it is equal to the link of the item.
+// * RSS category domain and Atom category scheme : they are mapped onto each
+// other when the source document has them, they are lost for JSON Feed.
+// * Atom and JSON Feed authors are reduced to the name of the first one, RSS
+// managingEditor and author become an Atom or JSON Feed author name.
+// * The type of the Atom Text constructs : RSS descriptions and Atom
+// summaries are written as html, JSON Feed summaries as text.
+// * Content : RSS content:encoded is HTML, text is escaped and the
+// out-of-line or other media types are lost. They are lost for JSON Feed too,
+// whose summary becomes the description when there is a content.
+// * Enclosures : RSS allows only one enclosure per item, the other ones are
+// lost. The JSON Feed attachment title and duration are lost.
+// * Media RSS : the contents are converted as enclosures, the thumbnails and
+// the other elements are lost.
+// * Atom links other than the first one and the enclosures, the RSS cloud,
+// ttl, skipHours and skipDays, the iTunes and Podcasting 2.0 elements, the
+// extensions and the registered modules of every format are not converted.
+//
+// The required fields which are absent from the source are filled :
+// * Atom id : the link, or a name-based urn:uuid built from the title.
+// * Atom updated : the feed date, the RSS lastBuildDate, the most recent entry
+// date, or the current time. The dates which can not be parsed are skipped,
+// and omitted from the entries.
+// * Atom author : the author of the feed, its title, or "unknown".
+// * RSS description : the title. RSS items without title nor description get
+// their link as title.
+// * JSON Feed id and content : the link or a urn:uuid, and the title.
+// The dates are converted into RFC 3339 for Atom and JSON Feed, they are kept
+// as they are for RSS. rss.Marshal writes them in the RFC 822 format.
+func Convert(feed *Feed, to FeedType) (*Feed, error) {
+	if feed.FeedType == to && feed.Version == convertVersions[to] {
+		return feed, nil
+	}
+
+	res := Feed{}
+
+	switch to {
+	case FeedTypeAtom:
+		res.Atom = feed.toAtom()
+		res.FeedType = FeedTypeAtom
+		res.parseAtom()
+	case FeedTypeRSS:
+		res.RSS = feed.toRSS()
+		res.FeedType = FeedTypeRSS
+		res.parseRSS()
+	case FeedTypeJSON:
+		res.JSON = feed.toJSON()
+		res.FeedType = FeedTypeJSON
+		res.parseJSON()
+	default:
+		return nil, errors.New("Conversion is only supported to RSS 2.0, " +
+			"Atom 1.0 or JSON Feed 1.1")
+	}
+
+	return &res, nil
+}
+
+func (f *Feed) toAtom() *atom.Feed {
+	a := atom.Feed{
+		IsDeclared: true,
+		Version:    "1.0",
+		XMLName:    xml.Name{Space: atom.Namespace10, Local: "feed"},
+	}
+
+	a.Lang = value(f.Language)
+	a.Generator.Content = value(f.Generator)
+	a.Logo.URI = value(f.Logo)
+	a.Rights = atomText(value(f.Rights), "text")
+	a.Subtitle = atomText(value(f.Description), f.descriptionType())
+	a.Title = atomText(value(f.Title), "text")
+
+	// atom:id and atom:updated are required
+	// source : https://tools.ietf.org/html/rfc4287#section-4.1.1
+	a.ID.URI = firstOf(value(f.Link), nameID(value(f.Title)))
+	a.Updated.DateTime = atomDate(value(f.Updated))
+	if a.Updated.DateTime == "" && f.RSS != nil {
+		a.Updated.DateTime = atomDate(f.RSS.Channel.LastBuildDate)
+	}
+	if a.Updated.DateTime == "" {
+		a.Updated.DateTime = f.newestEntryDate()
+	}
+	if a.Updated.DateTime == "" {
+		a.Updated.DateTime = time.Now().UTC().Format(time.RFC3339)
+	}
+
+	// atom:author is required on the feed when an entry has none, the feed
+	// always has one so every entry inherits it
+	// source : https://tools.ietf.org/html/rfc4287#section-4.1.1
+	a.Author = []atom.Person{{Name: firstOf(value(f.Author), value(f.Title),
+		"unknown")}}
+
+	if link := value(f.Link); link != "" {
+		a.Link = []atom.Link{{Href: link, Rel: "alternate"}}
+	}
+
+	for _, c := range f.categories(-1) {
+		a.Category = append(a.Category, atom.Category{Term: c.term,
+			Scheme: c.domain})
+	}
+
+	for index, e := range f.Entry {
+		entry := atom.Entry{}
+		entry.Title = atomText(value(e.Title), "text")
+		entry.Summary = atomText(value(e.Description), f.descriptionType())
+		entry.Content = e.Content.atom()
+		entry.ID.URI = firstOf(atomID(value(e.ID)), value(e.Link),
+			nameID(value(e.Title)+value(e.Published)))
+		entry.Published.DateTime = atomDate(value(e.Published))
+
+		// The unified model has no modification date, the publication date
+		// is the closest one
+		entry.Updated.DateTime = firstOf(entry.Published.DateTime,
+			a.Updated.DateTime)
+
+		if author := value(e.Author); author != "" {
+			entry.Author = []atom.Person{{Name: author}}
+		}
+
+		if link := value(e.Link); link != "" {
+			entry.Link = []atom.Link{{Href: link, Rel: "alternate"}}
+		}
+
+		for _, enc := range e.Enclosures {
+			link := atom.Link{Href: value(enc.URL), Rel: "enclosure",
+				Type: value(enc.MIMEType)}
+			if size := length(enc.Length); size > 0 {
+				link.Length = strconv.FormatInt(size, 10)
+			}
+			entry.Link = append(entry.Link, link)
+		}
+
+		for _, c := range f.categories(index) {
+			entry.Category = append(entry.Category, atom.Category{Term: c.term,
+				Scheme: c.domain})
+		}
+
+		if url := value(e.Source.URL); url != "" {
+			entry.Source.ID.URI = url
+			entry.Source.Link = []atom.Link{{Href: url, Rel: "self"}}
+			entry.Source.Title = atomText(value(e.Source.Title), "text")
+		}
+
+		a.Entry = append(a.Entry, entry)
+	}
+
+	return &a
+}
+
+// atomDate returns the date in the RFC 3339 format of Atom, an empty string
+// if it can not be parsed
+// source : https://tools.ietf.org/html/rfc4287#section-3.3
+func atomDate(value string) string {
+	if _, err := date.Parse(value); err != nil {
+		return ""
+	}
+
+	return date.RFC3339(value)
+}
+
+// newestEntryDate returns the most recent publication date of the entries in
+// the RFC 3339 format, an empty string if none can be parsed
+func (f *Feed) newestEntryDate() string {
+	var newest time.Time
+	res := ""
+	for _, e := range f.Entry {
+		t, err := date.Parse(value(e.Published))
+		if err == nil && (res == "" || t.After(newest)) {
+			newest = t
+			res = date.RFC3339(value(e.Published))
+		}
+	}
+
+	return res
+}
+
+func (f *Feed) toRSS() *rss.RSS {
+	r := rss.RSS{
+		Version: rss.Version20,
+		XMLName: xml.Name{Local: "rss"},
+	}
+
+	c := &r.Channel
+	c.Copyright = value(f.Rights)
+	c.Generator = value(f.Generator)
+	c.Language = value(f.Language)
+	c.Link = value(f.Link)
+	c.ManagingEditor = value(f.Author)
+	c.PubDate = value(f.Updated)
+	c.Title = value(f.Title)
+
+	// title, link and description are required
+	// source : https://cyber.law.harvard.edu/rss/rss.html#requiredChannelElements
+	c.Description = firstOf(value(f.Description), c.Title)
+
+	// url, title and link of the image are required
+	if logo := value(f.Logo); logo != "" {
+		c.Image = rss.Image{URL: logo, Title: c.Title, Link: c.Link}
+	}
+
+	for _, cat := range f.categories(-1) {
+		c.Category = append(c.Category, rss.Category{Content: cat.term,
+			Domain: cat.domain})
+	}
+
+	for index, e := range f.Entry {
+		item := rss.Item{
+			Author:      value(e.Author),
+			Description: value(e.Description),
+			Link:        value(e.Link),
+			PubDate:     value(e.Published),
+			Title:       value(e.Title),
+		}
+
+		// All elements of an item are optional, however at least one of
+		// title or description must be present
+		if item.Title == "" && item.Description == "" {
+			item.Title = item.Link
+		}
+
+		if id := value(e.ID); id != "" {
+			item.GUID.Content = id
+			item.GUID.IsPermaLink = "false"
+			if id == item.Link {
+				item.GUID.IsPermaLink = "true"
+			}
+		}
+
+		item.Source.Title = value(e.Source.Title)
+		item.Source.URL = value(e.Source.URL)
+		item.Content = e.Content.html()
+
+		// An item has at most one enclosure
+		if len(e.Enclosures) > 0 {
+			enc := e.Enclosures[0]
+			item.Enclosure = rss.Enclosure{Length: length(enc.Length),
+				Type: value(enc.MIMEType), URL: value(enc.URL)}
+		}
+
+		for _, cat := range f.categories(index) {
+			item.Category = append(item.Category, rss.Category{Content: cat.term,
+				Domain: cat.domain})
+		}
+
+		c.Item = append(c.Item, item)
+	}
+
+	return &r
+}
+
+func (f *Feed) toJSON() *jsonfeed.Feed {
+	j := jsonfeed.Feed{
+		Description: value(f.Description),
+		HomePageURL: value(f.Link),
+		Icon:        value(f.Logo),
+		Language:    value(f.Language),
+		Title:       value(f.Title),
+		Version:     jsonfeed.Version11,
+	}
+
+	if author := value(f.Author); author != "" {
+		j.Authors = []jsonfeed.Author{{Name: author}}
+	}
+
+	j.Items = make([]jsonfeed.Item, 0, len(f.Entry))
+	for index, e := range f.Entry {
+		item := jsonfeed.Item{
+			DatePublished: date.RFC3339(value(e.Published)),
+			ExternalURL:   value(e.Source.URL),
+			Title:         value(e.Title),
+			URL:           value(e.Link),
+		}
+
+		// id is required and one of content_html or content_text MUST be
+		// present
+		// source : https://jsonfeed.org/version/1.1#items-a-name-items-a
+		item.ID = firstOf(value(e.ID), item.URL,
+			nameID(value(e.Title)+value(e.Published)))
+		switch {
+		case e.Content.Type == ContentTypeText:
+			item.ContentText = value(e.Content.Value)
+			item.Summary = value(e.Description)
+		case e.Content.html() != "":
+			item.ContentHTML = e.Content.html()
+			item.Summary = value(e.Description)
+		case f.descriptionType() == "html":
+			item.ContentHTML = value(e.Description)
+		default:
+			item.ContentText = value(e.Description)
+		}
+		if item.ContentHTML == "" && item.ContentText == "" {
+			item.ContentText = item.Title
+		}
+
+		if author := value(e.Author); author != "" {
+			item.Authors = []jsonfeed.Author{{Name: author}}
+		}
+
+		for _, enc := range e.Enclosures {
+			item.Attachments = append(item.Attachments, jsonfeed.Attachment{
+				MIMEType:    value(enc.MIMEType),
+				SizeInBytes: length(enc.Length),
+				URL:         value(enc.URL),
+			})
+		}
+
+		for _, cat := range f.categories(index) {
+			item.Tags = append(item.Tags, cat.term)
+		}
+
+		j.Items = append(j.Items, item)
+	}
+
+	return &j
+}
+
+// category is a category of the source document with its RSS domain or its
+// Atom scheme
+type category struct {
+	domain string
+	term   string
+}
+
+// categories returns the categories of the entry at the given index, or of
+// the feed if the index is negative. The source document is used when it has
+// more information than the unified model, the unified model when the source
+// has no category, e.g. for the Dublin Core subjects.
+func (f *Feed) categories(index int) []category {
+	var res []category
+
+	switch {
+	case f.RSS != nil:
+		cats := f.RSS.Channel.Category
+		if index >= 0 {
+			cats = f.RSS.Channel.Item[index].Category
+		}
+		for _, c := range cats {
+			res = append(res, category{domain: c.Domain, term: c.Content})
+		}
+	case f.Atom != nil:
+		cats := f.Atom.Category
+		if index >= 0 {
+			cats = f.Atom.Entry[index].Category
+		}
+		for _, c := range cats {
+			res = append(res, category{domain: c.Scheme,
+				term: firstOf(c.Term, c.Content)})
+		}
+	}
+
+	if len(res) == 0 {
+		cats := f.Category
+		if index >= 0 {
+			cats = f.Entry[index].Category
+		}
+		for _, c := range cats {
+			res = append(res, category{term: value(c)})
+		}
+	}
+
+	return res
+}
+
+// descriptionType returns the Atom type of the descriptions of the feed
+func (f *Feed) descriptionType() string {
+	if f.FeedType == FeedTypeJSON {
+		return "text"
+	}
+
+	return "html"
+}
+
+// atom returns the atom:content of the content
+func (c *Content) atom() atom.Content {
+	res := atom.Content{}
+
+	switch c.Type {
+	case ContentTypeNone:
+		return res
+	case ContentTypeSrc:
+		res.Src = value(c.Src)
+		res.Type = value(c.MIMEType)
+	case ContentTypeMedia:
+		res.Type = value(c.MIMEType)
+	default:
+		res.Type = c.Type.String()
+	}
+
+	res.Content = value(c.Value)
+	return res
+}
+
+// html returns the content as escaped HTML, empty for the out-of-line and the
+// other media types
+func (c *Content) html() string {
+	switch c.Type {
+	case ContentTypeHTML, ContentTypeXHTML:
+		return value(c.Value)
+	case ContentTypeText:
+		return html.EscapeString(value(c.Value))
+	}
+
+	return ""
+}
+
+func atomText(content string, textType string) atom.Text {
+	if content == "" {
+		return atom.Text{}
+	}
+
+	return atom.Text{Content: content, Type: textType}
+}
+
+// nameID returns a name-based (version 5) UUID URN, the same name always
+// gives the same id
+// source : https://tools.ietf.org/html/rfc4122#section-4.3
+func nameID(name string) string {
+	// Name space ID for URLs
+	ns := []byte{0x6b, 0xa7, 0xb8, 0x11, 0x9d, 0xad, 0x11, 0xd1,
+		0x80, 0xb4, 0x00, 0xc0, 0x4f, 0xd4, 0x30, 0xc8}
+
+	h := sha1.New()
+	h.Write(ns)
+	h.Write([]byte(name))
+	s := h.Sum(nil)
+
+	s[6] = (s[6] & 0x0f) | 0x50 // version 5
+	s[8] = (s[8] & 0x3f) | 0x80 // variant RFC 4122
+
+	return fmt.Sprintf("urn:uuid:%x-%x-%x-%x-%x", s[0:4], s[4:6], s[6:8],
+		s[8:10], s[10:16])
+}
+
+// atomID returns the id if it is an absolute IRI as required by Atom, or a
+// name-based urn:uuid built from it
+// source : https://tools.ietf.org/html/rfc4287#section-4.2.6
+func atomID(id string) string {
+	if id == "" {
+		return ""
+	}
+
+	if u, err := url.Parse(id); err == nil && u.IsAbs() {
+		return id
+	}
+
+	return nameID(id)
+}
+
+// firstOf returns the first non empty value
+func firstOf(values ...string) string {
+	for _, v := range values {
+		if v != "" {
+			return v
+		}
+	}
+
+	return ""
+}
+
+// length returns the size pointed by p, 0 if p is nil
+func length(p *int64) int64 {
+	if p == nil {
+		return 0
+	}
+
+	return *p
+}
+
+// value returns the string pointed by p, an empty string if p is nil
+func value(p *string) string {
+	if p == nil {
+		return ""
+	}
+
+	return *p
+}
@@ -0,0 +1,335 @@
+// This file is part of clutch.
+//
+// clutch is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// clutch is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with Foobar.  If not, see <http://www.gnu.org/licenses/>.
+
+package clutch
+
+import (
+	"io/ioutil"
+	"strings"
+	"testing"
+
+	"github.com/racam/clutch/atom"
+	"github.com/racam/clutch/rss"
+)
+
+func parseFile(t *testing.T, filename string) *Feed {
+	data, err := ioutil.ReadFile(filename)
+	if err != nil {
+		t.Fatalf("[Clutch][Unit] file '%s' : is missing", filename)
+	}
+
+	f, err := Parse(data)
+	if err != nil {
+		t.Fatalf("[Clutch][Unit] file '%s' : %s", filename, err)
+	}
+
+	return f
+}
+
+func TestConvertToAtom(t *testing.T) {
+	f := parseFile(t, "testdata/jsonfeed/unit_05_parse.json")
+
+	res, err := Convert(f, FeedTypeAtom)
+	if err != nil {
+		t.Fatalf("[Clutch][Convert] %s", err)
+	}
+
+	if res.FeedType != FeedTypeAtom || res.Atom == nil {
+		t.Fatalf("[Clutch][Convert] unexpected type %d", res.FeedType)
+	}
+
+	// The required atom:id and atom:updated are filled
+	if res.Atom.ID.URI != "https://example.org/" {
+		t.Errorf("[Clutch][Convert] id : unexpected '%s'", res.Atom.ID.URI)
+	}
+
+	if res.Atom.Updated.DateTime != "2020-01-02T03:04:05Z" {
+		t.Errorf("[Clutch][Convert] updated : unexpected '%s'",
+			res.Atom.Updated.DateTime)
+	}
+
+	if err := atom.Check(res.Atom); err != nil {
+		t.Errorf("[Clutch][Convert] atom.Check : %s", err)
+	}
+
+	if *res.Entry[0].Title != "Item 1" || *res.Entry[0].Author != "" ||
+		len(res.Entry[0].Category) != 2 {
+		t.Errorf("[Clutch][Convert] entry : unexpected %+v", res.Entry[0])
+	}
+
+	// The attachment becomes a link with the enclosure relation
+	links := res.Atom.Entry[0].Link
+	if len(links) != 2 || links[1].Rel != "enclosure" ||
+		links[1].Length != "1234" || links[1].Type != "audio/mpeg" {
+		t.Errorf("[Clutch][Convert] enclosure : unexpected %+v", links)
+	}
+
+	out, err := atom.Marshal(res.Atom)
+	if err != nil {
+		t.Fatalf("[Clutch][Convert] atom.Marshal : %s", err)
+	}
+
+	if _, err := atom.Parse(out); err != nil {
+		t.Errorf("[Clutch][Convert] atom.Parse : %s\n%s", err, out)
+	}
+}
+
+func TestConvertToAtomCheck(t *testing.T) {
+	f := parseFile(t, "testdata/rss/unit_15_convert_atom.xml")
+
+	res, err := Convert(f, FeedTypeAtom)
+	if err != nil {
+		t.Fatalf("[Clutch][Convert] %s", err)
+	}
+
+	if err := atom.Check(res.Atom); err != nil {
+		t.Errorf("[Clutch][Convert] atom.Check : %s", err)
+	}
+
+	if len(res.Atom.Author) != 1 || res.Atom.Author[0].Name != "Title" {
+		t.Errorf("[Clutch][Convert] author : unexpected %+v", res.Atom.Author)
+	}
+
+	if res.Atom.Updated.DateTime != "2006-01-02T15:04:05Z" ||
+		res.Atom.Entry[0].Published.DateTime != "2006-01-02T15:04:05-07:00" ||
+		res.Atom.Entry[1].Updated.DateTime != "2006-01-02T15:04:05Z" {
+		t.Errorf("[Clutch][Convert] dates : unexpected updated '%s' or "+
+			"entries %+v", res.Atom.Updated.DateTime, res.Atom.Entry)
+	}
+}
+
+func TestConvertToAtomBadDate(t *testing.T) {
+	f := parseFile(t, "testdata/rss/unit_17_convert_bad_date.xml")
+
+	res, err := Convert(f, FeedTypeAtom)
+	if err != nil {
+		t.Fatalf("[Clutch][Convert] %s", err)
+	}
+
+	if err := atom.Check(res.Atom); err != nil {
+		t.Errorf("[Clutch][Convert] atom.Check : %s", err)
+	}
+
+	// The unparseable pubDate is skipped for the lastBuildDate
+	if res.Atom.Updated.DateTime != "2006-01-03T15:04:05Z" {
+		t.Errorf("[Clutch][Convert] updated : unexpected '%s'",
+			res.Atom.Updated.DateTime)
+	}
+
+	if res.Atom.Entry[0].Published.DateTime != "" ||
+		res.Atom.Entry[1].Published.DateTime != "2006-01-02T15:04:05Z" {
+		t.Errorf("[Clutch][Convert] published : unexpected %+v",
+			res.Atom.Entry)
+	}
+
+	// Without lastBuildDate, the most recent entry date is used
+	f.RSS.Channel.LastBuildDate = ""
+	res, err = Convert(f, FeedTypeAtom)
+	if err != nil {
+		t.Fatalf("[Clutch][Convert] %s", err)
+	}
+
+	if res.Atom.Updated.DateTime != "2006-01-04T15:04:05Z" {
+		t.Errorf("[Clutch][Convert] updated : unexpected '%s'",
+			res.Atom.Updated.DateTime)
+	}
+}
+
+func TestConvertToRSS(t *testing.T) {
+	f := parseFile(t, "testdata/atom/unit_06_atom03.xml")
+
+	res, err := Convert(f, FeedTypeRSS)
+	if err != nil {
+		t.Fatalf("[Clutch][Convert] %s", err)
+	}
+
+	if res.FeedType != FeedTypeRSS || res.Version != rss.Version20 {
+		t.Fatalf("[Clutch][Convert] unexpected type %d version '%s'",
+			res.FeedType, res.Version)
+	}
+
+	// The atom:tagline of Atom 0.3 becomes the description
+	if res.RSS.Channel.Description != "<b>Tagline</b>" ||
+		res.RSS.Channel.ManagingEditor != "John Doe" {
+		t.Errorf("[Clutch][Convert] channel : unexpected %+v", res.RSS.Channel)
+	}
+
+	if len(res.RSS.Channel.Item) != 2 {
+		t.Fatalf("[Clutch][Convert] item : expected 2 items, actual %d",
+			len(res.RSS.Channel.Item))
+	}
+
+	// The base64 html of Atom 0.3 is decoded into the content:encoded
+	if res.RSS.Channel.Item[1].Content != "<p>Hello</p>" {
+		t.Errorf("[Clutch][Convert] content : unexpected '%s'",
+			res.RSS.Channel.Item[1].Content)
+	}
+
+	guid := res.RSS.Channel.Item[0].GUID
+	if guid.Content != "tag:example.org,2004:1" || guid.IsPermaLink != "false" {
+		t.Errorf("[Clutch][Convert] guid : unexpected %+v", guid)
+	}
+
+	out, err := rss.Marshal(res.RSS)
+	if err != nil {
+		t.Fatalf("[Clutch][Convert] rss.Marshal : %s", err)
+	}
+
+	if !strings.Contains(string(out), "<pubDate>Fri, 02 Jan 2004 03:04:05 +0100</pubDate>") {
+		t.Errorf("[Clutch][Convert] rss.Marshal : unexpected\n%s", out)
+	}
+}
+
+func TestConvertRSSVersion(t *testing.T) {
+	f := parseFile(t, "testdata/rss/unit_18_convert_rss091.xml")
+
+	res, err := Convert(f, FeedTypeRSS)
+	if err != nil {
+		t.Fatalf("[Clutch][Convert] %s", err)
+	}
+
+	if res == f || res.Version != rss.Version20 ||
+		res.RSS.Version != rss.Version20 {
+		t.Fatalf("[Clutch][Convert] unexpected version '%s'", res.Version)
+	}
+
+	if res.RSS.Channel.Title != "Title" || len(res.RSS.Channel.Item) != 1 {
+		t.Errorf("[Clutch][Convert] channel : unexpected %+v", res.RSS.Channel)
+	}
+
+	// A feed which has the requested type and version is kept
+	if same, _ := Convert(res, FeedTypeRSS); same != res {
+		t.Errorf("[Clutch][Convert] rss 2.0 : unexpected new feed")
+	}
+}
+
+func TestConvertEnclosures(t *testing.T) {
+	f := parseFile(t, "testdata/atom/unit_27_parse_enclosure.xml")
+
+	res, err := Convert(f, FeedTypeRSS)
+	if err != nil {
+		t.Fatalf("[Clutch][Convert] %s", err)
+	}
+
+	// RSS has only one enclosure per item
+	enc := res.RSS.Channel.Item[0].Enclosure
+	if enc.URL != "http://example.org/1.mp3" || enc.Length != 24986239 ||
+		enc.Type != "audio/mpeg" {
+		t.Errorf("[Clutch][Convert] RSS enclosure : unexpected %+v", enc)
+	}
+
+	res, err = Convert(f, FeedTypeJSON)
+	if err != nil {
+		t.Fatalf("[Clutch][Convert] %s", err)
+	}
+
+	attachments := res.JSON.Items[0].Attachments
+	if len(attachments) != 2 || attachments[1].URL != "http://example.org/1.ogg" ||
+		attachments[1].SizeInBytes != 0 {
+		t.Errorf("[Clutch][Convert] JSON attachments : unexpected %+v",
+			attachments)
+	}
+}
+
+func TestConvertContent(t *testing.T) {
+	f := parseFile(t, "testdata/atom/unit_28_parse_content.xml")
+
+	res, err := Convert(f, FeedTypeRSS)
+	if err != nil {
+		t.Fatalf("[Clutch][Convert] %s", err)
+	}
+
+	// The text is escaped, the out-of-line and media contents are lost
+	var expected = []string{"Plain &lt;text&gt;", "<p>HTML</p>",
+		`<div xmlns="http://www.w3.org/1999/xhtml"><p>XHTML</p></div>`,
+		"", "", ""}
+	for index, e := range expected {
+		if actual := res.RSS.Channel.Item[index].Content; actual != e {
+			t.Errorf("[Clutch][Convert] RSS content %d : expected '%s', "+
+				"actual '%s'", index, e, actual)
+		}
+	}
+
+	res, err = Convert(res, FeedTypeAtom)
+	if err != nil {
+		t.Fatalf("[Clutch][Convert] %s", err)
+	}
+
+	c := res.Atom.Entry[1].Content
+	if c.Type != "html" || c.Content != "<p>HTML</p>" {
+		t.Errorf("[Clutch][Convert] Atom content : unexpected %+v", c)
+	}
+
+	res, err = Convert(f, FeedTypeJSON)
+	if err != nil {
+		t.Fatalf("[Clutch][Convert] %s", err)
+	}
+
+	item := res.JSON.Items[0]
+	if item.ContentText != "Plain <text>" || item.ContentHTML != "" {
+		t.Errorf("[Clutch][Convert] JSON content : unexpected %+v", item)
+	}
+}
+
+func TestConvertToJSON(t *testing.T) {
+	f := parseFile(t, "testdata/rdf/unit_05_parse.xml")
+
+	res, err := Convert(f, FeedTypeJSON)
+	if err != nil {
+		t.Fatalf("[Clutch][Convert] %s", err)
+	}
+
+	if res.FeedType != FeedTypeJSON || res.Version != "1.1" {
+		t.Fatalf("[Clutch][Convert] unexpected type %d version '%s'",
+			res.FeedType, res.Version)
+	}
+
+	if len(res.JSON.Items) != 2 {
+		t.Fatalf("[Clutch][Convert] item : expected 2 items, actual %d",
+			len(res.JSON.Items))
+	}
+
+	// The required content is filled with the title
+	item := res.JSON.Items[1]
+	if item.ID != "http://example.org/2" || item.ContentHTML != "" ||
+		item.ContentText != "Item 2" {
+		t.Errorf("[Clutch][Convert] item : unexpected %+v", item)
+	}
+}
+
+func TestConvertUnsupported(t *testing.T) {
+	f := parseFile(t, "testdata/jsonfeed/unit_05_parse.json")
+
+	if res, err := Convert(f, FeedTypeRDF); res != nil || err == nil {
+		t.Errorf("[Clutch][Convert] RDF : expected an error")
+	}
+
+	if res, err := Convert(f, FeedTypeJSON); res != f || err != nil {
+		t.Errorf("[Clutch][Convert] JSON : expected the same feed")
+	}
+}
+
+func TestNameID(t *testing.T) {
+	// A version 5 UUID is stable and has the version digit
+	id := nameID("http://example.org/")
+	if !strings.HasPrefix(id, "urn:uuid:") || len(id) != len("urn:uuid:")+36 ||
+		id[len("urn:uuid:")+14] != '5' {
+		t.Errorf("[Clutch][Convert] nameID : unexpected '%s'", id)
+	}
+
+	if id != nameID("http://example.org/") {
+		t.Errorf("[Clutch][Convert] nameID : not stable")
+	}
+}
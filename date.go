@@ -0,0 +1,36 @@
+// This file is part of clutch.
+//
+// clutch is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// clutch is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with Foobar.  If not, see <http://www.gnu.org/licenses/>.
+
+package clutch
+
+import (
+	"time"
+
+	"github.com/racam/clutch/date"
+)
+
+// UpdatedTime returns the Updated date of the feed as a time.Time, the
+// original string is kept in Updated. The date is read by date.Parse which
+// accepts the formats of every feed type and the broken dates found in the
+// wild. date.ErrEmpty is returned when the feed has no date.
+func (f *Feed) UpdatedTime() (time.Time, error) {
+	return date.Parse(value(f.Updated))
+}
+
+// PublishedTime returns the Published date of the entry, see
+// Feed.UpdatedTime
+func (e *Entry) PublishedTime() (time.Time, error) {
+	return date.Parse(value(e.Published))
+}
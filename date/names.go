@@ -0,0 +1,142 @@
+// This file is part of clutch.
+//
+// clutch is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// clutch is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with Foobar.  If not, see <http://www.gnu.org/licenses/>.
+
+package date
+
+// zones maps the named time zones to their offset. The zones of RFC 822 come
+// first, then the ones found in the wild. The ambiguous names (IST, ...) are
+// not listed.
+// source : https://tools.ietf.org/html/rfc822#section-5.1
+var zones = map[string]string{
+	"UT":  "+0000",
+	"UTC": "+0000",
+	"GMT": "+0000",
+	"Z":   "+0000",
+	"EST": "-0500",
+	"EDT": "-0400",
+	"CST": "-0600",
+	"CDT": "-0500",
+	"MST": "-0700",
+	"MDT": "-0600",
+	"PST": "-0800",
+	"PDT": "-0700",
+
+	"AKST": "-0900",
+	"AKDT": "-0800",
+	"HST":  "-1000",
+	"WET":  "+0000",
+	"WEST": "+0100",
+	"BST":  "+0100",
+	"CET":  "+0100",
+	"CEST": "+0200",
+	"MET":  "+0100",
+	"MEST": "+0200",
+	"EET":  "+0200",
+	"EEST": "+0300",
+	"MSK":  "+0300",
+	"JST":  "+0900",
+	"KST":  "+0900",
+	"AEST": "+1000",
+	"AEDT": "+1100",
+	"NZST": "+1200",
+	"NZDT": "+1300",
+}
+
+// months maps the month names, in English and in the languages found in the
+// wild, to the English abbreviations understood by the time package. The keys
+// are lower case and without the trailing dot of the abbreviations.
+var months = map[string]string{
+	// English
+	"jan": "Jan", "january": "Jan",
+	"feb": "Feb", "february": "Feb",
+	"mar": "Mar", "march": "Mar",
+	"apr": "Apr", "april": "Apr",
+	"may": "May",
+	"jun": "Jun", "june": "Jun",
+	"jul": "Jul", "july": "Jul",
+	"aug": "Aug", "august": "Aug",
+	"sep": "Sep", "sept": "Sep", "september": "Sep",
+	"oct": "Oct", "october": "Oct",
+	"nov": "Nov", "november": "Nov",
+	"dec": "Dec", "december": "Dec",
+
+	// French
+	"janv": "Jan", "janvier": "Jan",
+	"fév": "Feb", "févr": "Feb", "février": "Feb", "fev": "Feb",
+	"fevr": "Feb", "fevrier": "Feb",
+	"mars": "Mar",
+	"avr":  "Apr", "avril": "Apr",
+	"mai":  "May",
+	"juin": "Jun",
+	"juil": "Jul", "juillet": "Jul",
+	"aoû": "Aug", "août": "Aug", "aout": "Aug",
+	"septembre": "Sep",
+	"octobre":   "Oct",
+	"novembre":  "Nov",
+	"déc":       "Dec", "décembre": "Dec", "decembre": "Dec",
+
+	// German
+	"januar": "Jan", "jän": "Jan", "jänner": "Jan",
+	"februar": "Feb",
+	"mär":     "Mar", "märz": "Mar", "maerz": "Mar",
+	"juni": "Jun",
+	"juli": "Jul",
+	"okt":  "Oct", "oktober": "Oct",
+	"dez": "Dec", "dezember": "Dec",
+
+	// Spanish
+	"ene": "Jan", "enero": "Jan",
+	"febrero": "Feb",
+	"marzo":   "Mar",
+	"abr":     "Apr", "abril": "Apr",
+	"mayo":  "May",
+	"junio": "Jun",
+	"julio": "Jul",
+	"ago":   "Aug", "agosto": "Aug",
+	"septiembre": "Sep", "setiembre": "Sep",
+	"octubre":   "Oct",
+	"noviembre": "Nov",
+	"dic":       "Dec", "diciembre": "Dec",
+
+	// Italian
+	"gen": "Jan", "gennaio": "Jan",
+	"febbraio": "Feb",
+	"aprile":   "Apr",
+	"mag":      "May", "maggio": "May",
+	"giu": "Jun", "giugno": "Jun",
+	"lug": "Jul", "luglio": "Jul",
+	"set": "Sep", "settembre": "Sep",
+	"ott": "Oct", "ottobre": "Oct",
+	"dicembre": "Dec",
+
+	// Portuguese
+	"janeiro":   "Jan",
+	"fevereiro": "Feb",
+	"março":     "Mar", "marco": "Mar",
+	"maio":     "May",
+	"junho":    "Jun",
+	"julho":    "Jul",
+	"setembro": "Sep",
+	"out":      "Oct", "outubro": "Oct",
+	"novembro": "Nov",
+	"dezembro": "Dec",
+
+	// Dutch
+	"januari":  "Jan",
+	"februari": "Feb",
+	"mrt":      "Mar", "maart": "Mar",
+	"mei":      "May",
+	"augustus": "Aug",
+}
@@ -0,0 +1,328 @@
+// This file is part of clutch.
+//
+// clutch is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// clutch is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with Foobar.  If not, see <http://www.gnu.org/licenses/>.
+
+// Package date parses the dates of the feeds. The specifications require
+// RFC 822 (RSS) or RFC 3339 (Atom, JSON Feed) dates, the feeds found in the
+// wild use many other formats.
+package date
+
+import (
+	"errors"
+	"regexp"
+	"strings"
+	"time"
+	"unicode"
+)
+
+// ErrEmpty is returned when the date to parse is empty
+var ErrEmpty = errors.New("Date is empty")
+
+// rfc822 matches a date-time of RFC 822 with a two or four digit year, as
+// updated by RFC 1123
+// source : https://tools.ietf.org/html/rfc822#section-5.1
+// source : https://tools.ietf.org/html/rfc1123#section-5.2.14
+var rfc822 = regexp.MustCompile(`^(?:(?:Mon|Tue|Wed|Thu|Fri|Sat|Sun), )?` +
+	`(\d{1,2}) (Jan|Feb|Mar|Apr|May|Jun|Jul|Aug|Sep|Oct|Nov|Dec) ` +
+	`(\d{2}|\d{4}) (\d{2}:\d{2})(:\d{2})? ` +
+	`([+-]\d{4}|UT|GMT|EST|EDT|CST|CDT|MST|MDT|PST|PDT|[A-IK-Z])$`)
+
+// rfc822Zone matches the named time zones of RFC 822
+var rfc822Zone = regexp.MustCompile(`^(?:UT|GMT|EST|EDT|CST|CDT|MST|MDT|PST|` +
+	`PDT|[A-IK-Z])$`)
+
+// layouts are the layouts tried by Parse once the date is normalized : the
+// day name is removed, the month names are English abbreviations and the
+// named zones are offsets
+var layouts = buildLayouts()
+
+func buildLayouts() []string {
+	res := []string{
+		// ISO 8601 and W3C-DTF
+		// source : https://www.w3.org/TR/NOTE-datetime
+		"2006-01-02T15:04:05Z07:00",
+		"2006-01-02T15:04:05Z0700",
+		"2006-01-02T15:04:05Z07",
+		"2006-01-02T15:04:05",
+		"2006-01-02T15:04Z07:00",
+		"2006-01-02T15:04Z0700",
+		"2006-01-02T15:04",
+		"2006-01-02 15:04:05Z07:00",
+		"2006-01-02 15:04:05Z0700",
+		"2006-01-02 15:04Z07:00",
+		"20060102T150405Z0700",
+		"20060102T150405",
+		"20060102T1504Z0700",
+		"20060102",
+		"2006-01-02",
+		"2006-01",
+		"2006",
+
+		// ANSI C and Unix dates
+		"Jan 2 15:04:05 2006",
+		"Jan 2 15:04:05 -0700 2006",
+	}
+
+	dates := []string{
+		"2 Jan 2006", "2 Jan 06", "Jan 2 2006", "Jan 2 06", "2-Jan-2006",
+		"2-Jan-06", "2006-01-02", "2006/01/02",
+	}
+	times := []string{"15:04:05", "15:04", "3:04:05 PM", "3:04 PM"}
+
+	for _, d := range dates {
+		for _, t := range times {
+			res = append(res, d+" "+t+" -0700", d+" "+t)
+		}
+		res = append(res, d)
+	}
+
+	return res
+}
+
+// Parse parses a date of a feed. The formats of the specifications are tried
+// first, then W3C-DTF, the ISO 8601 variants and the broken dates found in
+// the wild : day and month names in English, French, German, Spanish,
+// Italian, Portuguese or Dutch, wrong day names, named time zones, missing
+// seconds, two-digit years, 12-hour clock ... A date without time zone is in
+// UTC, a date without time is at midnight.
+func Parse(value string) (time.Time, error) {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return time.Time{}, ErrEmpty
+	}
+
+	if t, err := time.Parse(time.RFC3339, value); err == nil {
+		return t, nil
+	}
+
+	if t, err := ParseRFC822(value); err == nil {
+		return t, nil
+	}
+
+	normalized, zone := normalize(value)
+	for _, layout := range layouts {
+		t, err := time.Parse(layout, normalized)
+		if err != nil {
+			continue
+		}
+
+		if zone != "" {
+			_, offset := t.Zone()
+			t = t.In(time.FixedZone(zone, offset))
+		}
+
+		return t, nil
+	}
+
+	return time.Time{}, errors.New("Date '" + value + "' has an unknown format")
+}
+
+// ParseRFC3339 parses a date which MUST respect RFC 3339, like the dates of
+// Atom and JSON Feed
+// source : https://tools.ietf.org/html/rfc3339#section-5.6
+func ParseRFC3339(value string) (time.Time, error) {
+	if value == "" {
+		return time.Time{}, ErrEmpty
+	}
+
+	return time.Parse(time.RFC3339, value)
+}
+
+// ParseRFC822 parses a date which MUST respect RFC 822, like the dates of
+// RSS. The day name is optional and the year has two or four digits. The
+// named zones keep their name, the military ones are UTC as advised by
+// RFC 1123.
+func ParseRFC822(value string) (time.Time, error) {
+	if value == "" {
+		return time.Time{}, ErrEmpty
+	}
+
+	m := rfc822.FindStringSubmatch(value)
+	if m == nil {
+		return time.Time{}, errors.New("Date '" + value +
+			"' does not respect RFC 822")
+	}
+
+	day, month, year, clock, seconds, zone := m[1], m[2], m[3], m[4], m[5], m[6]
+	if seconds == "" {
+		seconds = ":00"
+	}
+
+	offset, named := zone, false
+	if o, ok := zoneOffset(zone); ok {
+		offset, named = o, true
+	}
+
+	layout := "2 Jan 2006 15:04:05 -0700"
+	if len(year) == 2 {
+		layout = "2 Jan 06 15:04:05 -0700"
+	}
+
+	t, err := time.Parse(layout, day+" "+month+" "+year+" "+clock+seconds+" "+
+		offset)
+	if err != nil {
+		return time.Time{}, err
+	}
+
+	if named {
+		_, o := t.Zone()
+		t = t.In(time.FixedZone(zone, o))
+	}
+
+	return t, nil
+}
+
+// RFC3339 returns the date in the RFC 3339 format required by Atom and JSON
+// Feed. A date which already respects it, or which can not be read, is
+// returned as it is.
+func RFC3339(value string) string {
+	value = strings.TrimSpace(value)
+	if _, err := ParseRFC3339(value); err == nil {
+		return value
+	}
+
+	t, err := Parse(value)
+	if err != nil {
+		return value
+	}
+
+	return t.Format(time.RFC3339)
+}
+
+// RFC1123 returns the date in the RFC 822 format required by RSS, with the
+// day name and a four digit year of RFC 1123. A named time zone of RFC 822 is
+// kept, the other zones are written as offsets, whatever the host one. A
+// date which already respects it, or which can not be read, is returned as it
+// is.
+// source : https://cyber.law.harvard.edu/rss/rss.html#optionalChannelElements
+func RFC1123(value string) string {
+	value = strings.TrimSpace(value)
+	if m := rfc822.FindStringSubmatch(value); m != nil &&
+		strings.Contains(value, ",") && len(m[3]) == 4 && m[5] != "" {
+		return value
+	}
+
+	t, err := Parse(value)
+	if err != nil {
+		return value
+	}
+
+	// The name of the zone is kept only when it is an RFC 822 one, the names
+	// of the host time zone, e.g. CET, are not
+	if name, _ := t.Zone(); rfc822Zone.MatchString(name) {
+		return t.Format(time.RFC1123)
+	}
+
+	return t.Format(time.RFC1123Z)
+}
+
+// normalize rewrites the date for the layouts : the day name is removed, the
+// month names become English abbreviations and the named zone becomes an
+// offset, its name is returned
+func normalize(value string) (string, string) {
+	// The day name is the first word when it is followed by a comma or when
+	// it is not a month name. "mar." is Tuesday in French or Spanish but also
+	// March.
+	if index := strings.IndexFunc(value, func(r rune) bool {
+		return !unicode.IsLetter(r) && r != '.'
+	}); index > 0 {
+		word := strings.ToLower(strings.TrimSuffix(value[:index], "."))
+		if _, ok := months[word]; !ok || value[index] == ',' {
+			value = value[index:]
+		}
+	}
+
+	value = strings.Replace(value, ",", " ", -1)
+
+	var tokens []string
+	var zone string
+	fields := strings.Fields(value)
+	for index, f := range fields {
+		last := index == len(fields)-1
+
+		// Comments, e.g. "+0100 (CET)"
+		if strings.HasPrefix(f, "(") {
+			break
+		}
+
+		f = strings.TrimSuffix(f, ".")
+
+		switch lower := strings.ToLower(f); {
+		case lower == "de" || lower == "del" || lower == "at" || lower == "à":
+			continue
+		case months[lower] != "":
+			f = months[lower]
+		case lower == "am" || lower == "pm":
+			f = strings.ToUpper(f)
+		}
+
+		// "GMT+01:00" or "UTC-5"
+		for _, prefix := range []string{"GMT", "UTC"} {
+			if strings.HasPrefix(f, prefix+"+") || strings.HasPrefix(f, prefix+"-") {
+				f = f[len(prefix):]
+			}
+		}
+
+		if o, ok := zoneOffset(f); ok && last && index > 0 {
+			f, zone = o, f
+		}
+
+		tokens = append(tokens, offset(f))
+	}
+
+	return strings.Join(tokens, " "), zone
+}
+
+// offset returns the numeric time zone as +hhmm, "+01:00" or "-5" are
+// converted and the other tokens are returned as they are
+func offset(token string) string {
+	if len(token) < 2 || (token[0] != '+' && token[0] != '-') {
+		return token
+	}
+
+	digits := strings.Replace(token[1:], ":", "", 1)
+	for _, r := range digits {
+		if r < '0' || r > '9' {
+			return token
+		}
+	}
+
+	switch len(digits) {
+	case 1:
+		return token[:1] + "0" + digits + "00"
+	case 2:
+		return token[:1] + digits + "00"
+	case 3:
+		return token[:1] + "0" + digits
+	case 4:
+		return token[:1] + digits
+	}
+
+	return token
+}
+
+// zoneOffset returns the offset of a named time zone, the military zones are
+// UTC as advised by RFC 1123
+// source : https://tools.ietf.org/html/rfc1123#section-5.2.14
+func zoneOffset(name string) (string, bool) {
+	if o, ok := zones[strings.ToUpper(name)]; ok {
+		return o, true
+	}
+
+	if len(name) == 1 && name[0] >= 'A' && name[0] <= 'Z' && name[0] != 'J' {
+		return "+0000", true
+	}
+
+	return "", false
+}
@@ -0,0 +1,198 @@
+// This file is part of clutch.
+//
+// clutch is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// clutch is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with Foobar.  If not, see <http://www.gnu.org/licenses/>.
+
+package date
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParse(t *testing.T) {
+	var dates = []struct {
+		value    string // input date
+		expected string // expected date in RFC 3339, empty if it is invalid
+	}{
+		// RFC 3339 and W3C-DTF
+		{"2006-01-02T15:04:05Z", "2006-01-02T15:04:05Z"},
+		{"2006-01-02T15:04:05.123+01:00", "2006-01-02T15:04:05.123+01:00"},
+		{"2006-01-02T15:04+01:00", "2006-01-02T15:04:00+01:00"},
+		{"2006-01-02", "2006-01-02T00:00:00Z"},
+		{"2006-01", "2006-01-01T00:00:00Z"},
+		{"2006", "2006-01-01T00:00:00Z"},
+
+		// ISO 8601
+		{"2006-01-02T15:04:05+0100", "2006-01-02T15:04:05+01:00"},
+		{"2006-01-02T15:04:05+01", "2006-01-02T15:04:05+01:00"},
+		{"2006-01-02T15:04:05", "2006-01-02T15:04:05Z"},
+		{"2006-01-02 15:04:05Z", "2006-01-02T15:04:05Z"},
+		{"2006-01-02 15:04:05 +0100", "2006-01-02T15:04:05+01:00"},
+		{"2006-01-02 15:04:05", "2006-01-02T15:04:05Z"},
+		{"20060102T150405Z", "2006-01-02T15:04:05Z"},
+		{"20060102", "2006-01-02T00:00:00Z"},
+
+		// RFC 822 and RFC 1123
+		{"Mon, 02 Jan 2006 15:04:05 +0100", "2006-01-02T15:04:05+01:00"},
+		{"Mon, 02 Jan 2006 15:04:05 GMT", "2006-01-02T15:04:05Z"},
+		{"Mon, 02 Jan 2006 15:04:05 EST", "2006-01-02T15:04:05-05:00"},
+		{"02 Jan 06 15:04 PDT", "2006-01-02T15:04:00-07:00"},
+		{"2 Jan 2006 15:04:05 Z", "2006-01-02T15:04:05Z"},
+
+		// Broken dates
+		{"Tue, 02 Jan 2006 15:04:05 +0100", "2006-01-02T15:04:05+01:00"},
+		{"Monday, 2 January 2006 15:04:05 +0100", "2006-01-02T15:04:05+01:00"},
+		{"Mon, 2 Jan 2006 15:04:05 +01:00", "2006-01-02T15:04:05+01:00"},
+		{"Mon, 2 Jan 2006 15:04 +0100", "2006-01-02T15:04:00+01:00"},
+		{"Mon, 2 Jan 2006 15:04:05", "2006-01-02T15:04:05Z"},
+		{"Mon, 2 Jan 2006", "2006-01-02T00:00:00Z"},
+		{"Mon,  2 Jan 2006 15:04:05 CET", "2006-01-02T15:04:05+01:00"},
+		{"Mon, 2 Jan 2006 15:04:05 GMT+01:00", "2006-01-02T15:04:05+01:00"},
+		{"Mon, 2 Jan 2006 15:04:05 +0100 (CET)", "2006-01-02T15:04:05+01:00"},
+		{"Mon, 2 Sept 2006 15:04:05 +0100", "2006-09-02T15:04:05+01:00"},
+		{"Mon, 2 Jan 99 15:04:05 +0100", "1999-01-02T15:04:05+01:00"},
+		{"January 2, 2006 3:04 PM", "2006-01-02T15:04:00Z"},
+		{"Jan 2 15:04:05 2006", "2006-01-02T15:04:05Z"},
+		{"2-Jan-2006 15:04:05", "2006-01-02T15:04:05Z"},
+		{"2006/01/02 15:04", "2006-01-02T15:04:00Z"},
+
+		// Localized dates
+		{"lun., 02 janv. 2006 15:04:05 +0100", "2006-01-02T15:04:05+01:00"},
+		{"Lundi 2 février 2006 15:04", "2006-02-02T15:04:00Z"},
+		{"Mo, 02 Mär 2006 15:04:05 +0100", "2006-03-02T15:04:05+01:00"},
+		{"2. Dezember 2006 15:04", "2006-12-02T15:04:00Z"},
+		{"mar., 02 ene. 2006 15:04:05 +0100", "2006-01-02T15:04:05+01:00"},
+		{"2 de agosto de 2006", "2006-08-02T00:00:00Z"},
+		{"lun, 02 giu 2006 15:04:05 +0200", "2006-06-02T15:04:05+02:00"},
+		{"seg, 02 out 2006 15:04:05 -0300", "2006-10-02T15:04:05-03:00"},
+		{"2 mei 2006", "2006-05-02T00:00:00Z"},
+
+		// Invalid dates
+		{"", ""},
+		{"yesterday", ""},
+		{"02/01/2006", ""},
+		{"Mon, 32 Jan 2006 15:04:05 +0100", ""},
+	}
+
+	for _, d := range dates {
+		res, err := Parse(d.value)
+
+		if d.expected == "" {
+			if err == nil {
+				t.Errorf("[Date][Unit][Parse] '%s' : expected an error, actual %s",
+					d.value, res.Format(time.RFC3339Nano))
+			}
+			continue
+		}
+
+		if err != nil {
+			t.Errorf("[Date][Unit][Parse] '%s' : %s", d.value, err)
+			continue
+		}
+
+		if actual := res.Format(time.RFC3339Nano); actual != d.expected {
+			t.Errorf("[Date][Unit][Parse] '%s' : expected '%s', actual '%s'",
+				d.value, d.expected, actual)
+		}
+	}
+}
+
+func TestParseStrict(t *testing.T) {
+	var dates = []struct {
+		value   string // input date
+		rfc822  bool   // expected validity for RFC 822
+		rfc3339 bool   // expected validity for RFC 3339
+	}{
+		{"Mon, 02 Jan 2006 15:04:05 +0100", true, false},
+		{"02 Jan 06 15:04 EST", true, false},
+		{"Mon, 02 Jan 2006 15:04:05 CET", false, false},
+		{"Mon, 2 January 2006 15:04:05 +0100", false, false},
+		{"2006-01-02T15:04:05Z", false, true},
+		{"2006-01-02T15:04:05.5-07:00", false, true},
+		{"2006-01-02 15:04:05Z", false, false},
+		{"2006-01-02", false, false},
+		{"", false, false},
+	}
+
+	for _, d := range dates {
+		if _, err := ParseRFC822(d.value); (err == nil) != d.rfc822 {
+			t.Errorf("[Date][Unit][ParseRFC822] '%s' : expected %t, actual %t",
+				d.value, d.rfc822, err == nil)
+		}
+
+		if _, err := ParseRFC3339(d.value); (err == nil) != d.rfc3339 {
+			t.Errorf("[Date][Unit][ParseRFC3339] '%s' : expected %t, actual %t",
+				d.value, d.rfc3339, err == nil)
+		}
+	}
+}
+
+func TestFormat(t *testing.T) {
+	var dates = []struct {
+		value   string // input date
+		rfc1123 string // expected RFC1123 result
+		rfc3339 string // expected RFC3339 result
+	}{
+		{"Mon, 02 Jan 2006 15:04:05 +0100", "Mon, 02 Jan 2006 15:04:05 +0100",
+			"2006-01-02T15:04:05+01:00"},
+		{"Mon, 02 Jan 06 15:04 EST", "Mon, 02 Jan 2006 15:04:00 EST",
+			"2006-01-02T15:04:00-05:00"},
+		{"2006-01-02T15:04:05Z", "Mon, 02 Jan 2006 15:04:05 +0000",
+			"2006-01-02T15:04:05Z"},
+		{"lun., 02 janv. 2006 15:04 +0100", "Mon, 02 Jan 2006 15:04:00 +0100",
+			"2006-01-02T15:04:00+01:00"},
+		{" unknown ", "unknown", "unknown"},
+	}
+
+	for _, d := range dates {
+		if res := RFC1123(d.value); res != d.rfc1123 {
+			t.Errorf("[Date][Unit][RFC1123] '%s' : expected '%s', actual '%s'",
+				d.value, d.rfc1123, res)
+		}
+
+		if res := RFC3339(d.value); res != d.rfc3339 {
+			t.Errorf("[Date][Unit][RFC3339] '%s' : expected '%s', actual '%s'",
+				d.value, d.rfc3339, res)
+		}
+	}
+}
+
+func TestRFC1123Local(t *testing.T) {
+	local := time.Local
+	defer func() { time.Local = local }()
+
+	// time.Parse names the offsets of the host time zone
+	time.Local = time.FixedZone("CET", 3600)
+
+	var dates = []struct {
+		value    string // input date
+		expected string // expected result
+	}{
+		{"2006-01-02T15:04:05+01:00", "Mon, 02 Jan 2006 15:04:05 +0100"},
+		{"2006-01-02T15:04:05-05:00", "Mon, 02 Jan 2006 15:04:05 -0500"},
+		{"02 Jan 2006 15:04:05 GMT", "Mon, 02 Jan 2006 15:04:05 GMT"},
+	}
+
+	for _, d := range dates {
+		res := RFC1123(d.value)
+		if res != d.expected {
+			t.Errorf("[Date][Unit][RFC1123] '%s' : expected '%s', actual '%s'",
+				d.value, d.expected, res)
+		}
+
+		if _, err := ParseRFC822(res); err != nil {
+			t.Errorf("[Date][Unit][RFC1123] '%s' : %s", d.value, err)
+		}
+	}
+}
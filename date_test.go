@@ -0,0 +1,52 @@
+// This file is part of clutch.
+//
+// clutch is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// clutch is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with Foobar.  If not, see <http://www.gnu.org/licenses/>.
+
+package clutch
+
+import (
+	"testing"
+	"time"
+
+	"github.com/racam/clutch/date"
+)
+
+func TestDates(t *testing.T) {
+	updated := "lun., 02 janv. 2006 15:04 +0100"
+	published := "2006-01-02T15:04:05Z"
+	f := Feed{Updated: &updated, Entry: []Entry{{Published: &published}, {}}}
+
+	res, err := f.UpdatedTime()
+	expected := time.Date(2006, time.January, 2, 14, 4, 0, 0, time.UTC)
+	if err != nil || !res.Equal(expected) {
+		t.Errorf("[Clutch][Unit][Date] Feed : expected %s, actual %s (%v)",
+			expected, res, err)
+	}
+
+	res, err = f.Entry[0].PublishedTime()
+	expected = time.Date(2006, time.January, 2, 15, 4, 5, 0, time.UTC)
+	if err != nil || !res.Equal(expected) {
+		t.Errorf("[Clutch][Unit][Date] Entry : expected %s, actual %s (%v)",
+			expected, res, err)
+	}
+
+	if _, err = f.Entry[1].PublishedTime(); err != date.ErrEmpty {
+		t.Errorf("[Clutch][Unit][Date] Entry : expected '%s', actual '%v'",
+			date.ErrEmpty, err)
+	}
+
+	if *f.Updated != updated {
+		t.Errorf("[Clutch][Unit][Date] Feed : the original date is modified")
+	}
+}
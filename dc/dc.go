@@ -0,0 +1,124 @@
+// This file is part of clutch.
+//
+// clutch is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// clutch is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with Foobar.  If not, see <http://www.gnu.org/licenses/>.
+
+// Package dc please Refer to https://www.dublincore.org/specifications/dublin-core/dces/
+package dc
+
+import (
+	"encoding/xml"
+
+	"github.com/racam/clutch/ext"
+)
+
+// Namespace is the namespace of the Dublin Core Metadata Element Set
+// source : https://www.dublincore.org/specifications/dublin-core/dces/
+const Namespace string = "http://purl.org/dc/elements/1.1/"
+
+// NamespaceTerms is the namespace of the DCMI Metadata Terms, which repeats
+// the 15 elements and refines them
+// source : https://www.dublincore.org/specifications/dublin-core/dcmi-terms/
+const NamespaceTerms string = "http://purl.org/dc/terms/"
+
+func init() {
+	newModule := func() ext.Module { return &DublinCore{} }
+	ext.RegisterBuiltin(Namespace, newModule)
+	ext.RegisterBuiltin(NamespaceTerms, newModule)
+}
+
+// DublinCore is a Dublin Core structure like describe in
+// https://www.dublincore.org/specifications/dublin-core/dces/
+// Every element may be repeated. The elements of the DCMI Metadata Terms
+// namespace fill the same fields, except the refinements of the date.
+type DublinCore struct {
+	Contributor []string
+	Coverage    []string
+	Created     []string // terms only
+	Creator     []string
+	Date        []string
+	Description []string
+	Format      []string
+	Identifier  []string
+	Issued      []string // terms only
+	Language    []string
+	Modified    []string // terms only
+	Publisher   []string
+	Relation    []string
+	Rights      []string
+	Source      []string
+	Subject     []string
+	Title       []string
+	Type        []string
+}
+
+// Element returns the value into which the element must be decoded, nil if
+// it is not a Dublin Core element. The value is appended to the field of the
+// element.
+func (d *DublinCore) Element(name xml.Name) interface{} {
+	if name.Space != Namespace && name.Space != NamespaceTerms {
+		return nil
+	}
+
+	var field *[]string
+	switch name.Local {
+	case "contributor":
+		field = &d.Contributor
+	case "coverage":
+		field = &d.Coverage
+	case "creator":
+		field = &d.Creator
+	case "date":
+		field = &d.Date
+	case "description":
+		field = &d.Description
+	case "format":
+		field = &d.Format
+	case "identifier":
+		field = &d.Identifier
+	case "language":
+		field = &d.Language
+	case "publisher":
+		field = &d.Publisher
+	case "relation":
+		field = &d.Relation
+	case "rights":
+		field = &d.Rights
+	case "source":
+		field = &d.Source
+	case "subject":
+		field = &d.Subject
+	case "title":
+		field = &d.Title
+	case "type":
+		field = &d.Type
+	}
+
+	if name.Space == NamespaceTerms {
+		switch name.Local {
+		case "created":
+			field = &d.Created
+		case "issued":
+			field = &d.Issued
+		case "modified":
+			field = &d.Modified
+		}
+	}
+
+	if field == nil {
+		return nil
+	}
+
+	*field = append(*field, "")
+	return &(*field)[len(*field)-1]
+}
@@ -0,0 +1,71 @@
+// This file is part of clutch.
+//
+// clutch is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// clutch is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with Foobar.  If not, see <http://www.gnu.org/licenses/>.
+
+package dc
+
+import (
+	"encoding/xml"
+	"io/ioutil"
+	"reflect"
+	"testing"
+
+	"github.com/racam/clutch/internal/xmltest"
+)
+
+var prefix = "../testdata/dc/"
+
+func TestElement(t *testing.T) {
+	filename := "unit_01_elements.xml"
+	data, err := ioutil.ReadFile(prefix + filename)
+	if err != nil {
+		t.Fatalf("[DC][Unit][Element] file '%s' : is missing", prefix+filename)
+	}
+
+	actual := DublinCore{}
+	skipped, err := xmltest.Decode(data, actual.Element)
+	if err != nil {
+		t.Fatalf("[DC][Unit][Element] file '%s' : %s", filename, err)
+	}
+
+	expected := DublinCore{
+		Creator:  []string{"John Doe", "Jane Doe"},
+		Date:     []string{"2006-01-02T15:04:05Z"},
+		Modified: []string{"2006-01-03T15:04:05Z"},
+		Rights:   []string{"Copyright 2006"},
+		Subject:  []string{"go", "feeds"},
+	}
+
+	if !reflect.DeepEqual(actual, expected) {
+		t.Errorf("[DC][Unit][Element] expected %+v, actual %+v", expected,
+			actual)
+	}
+
+	if skipped != 2 {
+		t.Errorf("[DC][Unit][Element] expected 2 skipped elements, actual %d",
+			skipped)
+	}
+}
+
+func TestElementName(t *testing.T) {
+	d := DublinCore{}
+
+	if v := d.Element(xml.Name{Local: "creator"}); v != nil {
+		t.Errorf("[DC][Unit][Element] creator without namespace : expected nil")
+	}
+
+	if v := d.Element(xml.Name{Space: Namespace, Local: "created"}); v != nil {
+		t.Errorf("[DC][Unit][Element] dc:created : expected nil")
+	}
+}
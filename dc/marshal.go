@@ -0,0 +1,60 @@
+// This file is part of clutch.
+//
+// clutch is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// clutch is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with Foobar.  If not, see <http://www.gnu.org/licenses/>.
+
+package dc
+
+import (
+	"encoding/xml"
+
+	"github.com/racam/clutch/internal/xmlenc"
+)
+
+// Write writes the elements in the Dublin Core namespace, and the refinements
+// of the date in the DCMI Metadata Terms namespace. The empty values are
+// omitted.
+func (d *DublinCore) Write(enc *xmlenc.Encoder) {
+	writeElements(enc, Namespace, "title", d.Title)
+	writeElements(enc, Namespace, "creator", d.Creator)
+	writeElements(enc, Namespace, "subject", d.Subject)
+	writeElements(enc, Namespace, "description", d.Description)
+	writeElements(enc, Namespace, "publisher", d.Publisher)
+	writeElements(enc, Namespace, "contributor", d.Contributor)
+	writeElements(enc, Namespace, "date", d.Date)
+	writeElements(enc, NamespaceTerms, "created", d.Created)
+	writeElements(enc, NamespaceTerms, "issued", d.Issued)
+	writeElements(enc, NamespaceTerms, "modified", d.Modified)
+	writeElements(enc, Namespace, "type", d.Type)
+	writeElements(enc, Namespace, "format", d.Format)
+	writeElements(enc, Namespace, "identifier", d.Identifier)
+	writeElements(enc, Namespace, "source", d.Source)
+	writeElements(enc, Namespace, "language", d.Language)
+	writeElements(enc, Namespace, "relation", d.Relation)
+	writeElements(enc, Namespace, "coverage", d.Coverage)
+	writeElements(enc, Namespace, "rights", d.Rights)
+}
+
+func writeElements(enc *xmlenc.Encoder, space string, local string,
+	values []string) {
+	n := xml.Name{Space: space, Local: local}
+	for _, v := range values {
+		if v == "" {
+			continue
+		}
+
+		enc.Start(n)
+		enc.Text(v)
+		enc.End(n)
+	}
+}
@@ -0,0 +1,55 @@
+// This file is part of clutch.
+//
+// clutch is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// clutch is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with Foobar.  If not, see <http://www.gnu.org/licenses/>.
+
+package dc
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/racam/clutch/internal/xmlenc"
+)
+
+func TestWrite(t *testing.T) {
+	d := DublinCore{
+		Creator:  []string{"John Doe", ""},
+		Modified: []string{"2006-01-03T15:04:05Z"},
+		Subject:  []string{"go & feeds"},
+	}
+
+	var buf bytes.Buffer
+	enc := xmlenc.NewEncoder(&buf)
+	d.Write(enc)
+	if err := enc.Close(); err != nil {
+		t.Fatalf("[DC][Marshal] %s", err)
+	}
+
+	var expected = []string{
+		`<creator xmlns="http://purl.org/dc/elements/1.1/">John Doe</creator>`,
+		`<subject xmlns="http://purl.org/dc/elements/1.1/">go &amp; feeds</subject>`,
+		`<modified xmlns="http://purl.org/dc/terms/">2006-01-03T15:04:05Z</modified>`,
+	}
+
+	for _, e := range expected {
+		if !strings.Contains(buf.String(), e) {
+			t.Errorf("[DC][Marshal] expected '%s' in\n%s", e, buf.String())
+		}
+	}
+
+	if strings.Count(buf.String(), "<creator") != 1 {
+		t.Errorf("[DC][Marshal] empty creator is written\n%s", buf.String())
+	}
+}
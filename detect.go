@@ -0,0 +1,102 @@
+// This file is part of clutch.
+//
+// clutch is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// clutch is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with Foobar.  If not, see <http://www.gnu.org/licenses/>.
+
+package clutch
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"encoding/xml"
+	"strings"
+
+	"github.com/racam/clutch/atom"
+	"github.com/racam/clutch/internal/xmldec"
+	"github.com/racam/clutch/jsonfeed"
+	"github.com/racam/clutch/rdf"
+	"github.com/racam/clutch/rss"
+)
+
+// DetectFeedType returns the type and the version of the document, like Parse
+// would set FeedType and Version, without building the feed. Only the
+// beginning of the XML documents is read : the root element, and the children
+// of rdf:RDF until the channel. The RSS version is the version attribute, the
+// Atom version is "1.0" or "0.3" for both the feed and the entry documents,
+// the RSS 1.0 version is "1.0" or "0.90" and the JSON Feed version is "1.0" or
+// "1.1". FeedTypeUnknown and an empty version are returned when the document
+// is not recognized. An unknown RSS version is returned as it is, Parse returns
+// an *UnsupportedVersionError for it.
+func DetectFeedType(data []byte) (FeedType, string) {
+	c, err := firstByte(bufio.NewReader(bytes.NewReader(data)))
+	if err != nil {
+		return FeedTypeUnknown, ""
+	}
+
+	if c == '{' {
+		var j struct {
+			Version string `json:"version"`
+		}
+
+		if json.Unmarshal(data, &j) != nil {
+			return FeedTypeUnknown, ""
+		}
+
+		if v, ok := jsonfeed.ShortVersion(j.Version); ok {
+			return FeedTypeJSON, v
+		}
+
+		return FeedTypeUnknown, ""
+	}
+
+	d := xmldec.NewDecoder(bytes.NewReader(data))
+	root, err := xmldec.RootElement(d)
+	if err != nil {
+		return FeedTypeUnknown, ""
+	}
+
+	switch feedType := xmlFeedType(root); feedType {
+	case FeedTypeRSS:
+		return feedType, rss.DeclaredVersion(root)
+	case FeedTypeAtom:
+		if root.Name.Space == atom.Namespace03 {
+			return feedType, "0.3"
+		}
+		return feedType, "1.0"
+	case FeedTypeRDF:
+		if v := rdf.DeclaredVersion(d); v != "" {
+			return feedType, v
+		}
+	}
+
+	return FeedTypeUnknown, ""
+}
+
+// xmlFeedType returns the type of the XML document whose root element is
+// start. Like atom.IsDeclared, an Atom root element must be in the namespace
+// of Atom 1.0 or 0.3. Like the rss package, the rss element may have any case.
+func xmlFeedType(start xml.StartElement) FeedType {
+	switch {
+	case strings.EqualFold(start.Name.Local, "rss"):
+		return FeedTypeRSS
+	case (start.Name.Local == "feed" || start.Name.Local == "entry") &&
+		(start.Name.Space == atom.Namespace10 ||
+			start.Name.Space == atom.Namespace03):
+		return FeedTypeAtom
+	case start.Name.Space == rdf.NamespaceRDF && start.Name.Local == "RDF":
+		return FeedTypeRDF
+	}
+
+	return FeedTypeUnknown
+}
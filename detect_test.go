@@ -0,0 +1,129 @@
+// This file is part of clutch.
+//
+// clutch is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// clutch is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with Foobar.  If not, see <http://www.gnu.org/licenses/>.
+
+package clutch
+
+import (
+	"errors"
+	"io/ioutil"
+	"path/filepath"
+	"testing"
+)
+
+func TestDetectFeedType(t *testing.T) {
+	var files = []struct {
+		filename string   // input file
+		feedType FeedType // expected type
+		version  string   // expected version
+	}{
+		{"testdata/rss/unit_01_IsDeclared.xml", FeedTypeRSS, "2.0"},
+		{"testdata/rss/unit_02_IsDeclared.xml", FeedTypeRSS, "0.92"},
+		{"testdata/rss/unit_04_IsDeclared.xml", FeedTypeRSS, "0.91"},
+		{"testdata/rss/unit_16_root_casing.xml", FeedTypeRSS, "2.0"},
+		{"testdata/rdf/unit_01_IsDeclared.xml", FeedTypeRDF, "1.0"},
+		{"testdata/rdf/unit_02_IsDeclared.xml", FeedTypeRDF, "0.90"},
+		{"testdata/rdf/unit_03_IsDeclared.xml", FeedTypeUnknown, ""},
+		{"testdata/rdf/unit_04_IsDeclared.xml", FeedTypeUnknown, ""},
+		{"testdata/atom/unit_01_IsDeclared.xml", FeedTypeAtom, "1.0"},
+		{"testdata/atom/unit_02_IsDeclared.xml", FeedTypeAtom, "1.0"},
+		{"testdata/atom/unit_04_not_atom.xml", FeedTypeUnknown, ""},
+		{"testdata/atom/unit_05_IsDeclared.xml", FeedTypeAtom, "0.3"},
+		{"testdata/atom/unit_34_not_atom_namespace.xml", FeedTypeUnknown, ""},
+		{"testdata/atom/integ_empty_entry.xml", FeedTypeUnknown, ""},
+		{"testdata/jsonfeed/unit_01_IsDeclared.json", FeedTypeJSON, "1.1"},
+		{"testdata/jsonfeed/unit_02_IsDeclared.json", FeedTypeJSON, "1.0"},
+		{"testdata/jsonfeed/unit_03_IsDeclared.json", FeedTypeUnknown, ""},
+		{"testdata/jsonfeed/unit_04_IsDeclared.json", FeedTypeUnknown, ""},
+	}
+
+	for _, file := range files {
+		data, err := ioutil.ReadFile(file.filename)
+		if err != nil {
+			t.Errorf("[Clutch][Unit][Detect] file '%s' : is missing",
+				file.filename)
+			continue
+		}
+
+		feedType, version := DetectFeedType(data)
+		if feedType != file.feedType || version != file.version {
+			t.Errorf("[Clutch][Unit][Detect] file '%s' : expected (%d, %s), "+
+				"actual (%d, %s)", file.filename, file.feedType, file.version,
+				feedType, version)
+		}
+	}
+}
+
+// TestDetectNotAtom checks that the feed and entry root elements outside the
+// Atom namespaces are not parsed as Atom
+func TestDetectNotAtom(t *testing.T) {
+	var files = []string{"testdata/atom/unit_34_not_atom_namespace.xml",
+		"testdata/atom/integ_empty_entry.xml"}
+
+	for _, filename := range files {
+		data, err := ioutil.ReadFile(filename)
+		if err != nil {
+			t.Fatalf("[Clutch][Unit][Detect] file '%s' : is missing", filename)
+		}
+
+		if _, err := Parse(data); !errors.Is(err, ErrUnknownFormat) {
+			t.Errorf("[Clutch][Unit][Detect] file '%s' : expected "+
+				"ErrUnknownFormat, actual %v", filename, err)
+		}
+	}
+}
+
+// TestDetectFeedTypeParse checks that DetectFeedType agrees with Parse on the
+// whole testdata directory, the unsupported versions included
+func TestDetectFeedTypeParse(t *testing.T) {
+	files, _ := filepath.Glob("testdata/*/*")
+	for _, file := range files {
+		if ext := filepath.Ext(file); ext != ".xml" && ext != ".json" {
+			continue
+		}
+
+		data, err := ioutil.ReadFile(file)
+		if err != nil {
+			t.Errorf("[Clutch][Unit][Detect] %s", err)
+			continue
+		}
+
+		expectedType, expectedVersion := FeedTypeUnknown, ""
+		f, err := Parse(data)
+		var versionErr *UnsupportedVersionError
+		if err == nil {
+			expectedType, expectedVersion = f.FeedType, f.Version
+		} else if errors.As(err, &versionErr) {
+			expectedType, expectedVersion = versionErr.FeedType, versionErr.Version
+		}
+
+		feedType, version := DetectFeedType(data)
+		if feedType != expectedType || version != expectedVersion {
+			t.Errorf("[Clutch][Unit][Detect] file '%s' : Parse gives (%d, %s), "+
+				"DetectFeedType gives (%d, %s)", file, expectedType,
+				expectedVersion, feedType, version)
+		}
+	}
+}
+
+func BenchmarkDetectFeedType(b *testing.B) {
+	documents := corpus(b)
+	b.ResetTimer()
+
+	for n := 0; n < b.N; n++ {
+		for _, data := range documents {
+			DetectFeedType(data)
+		}
+	}
+}
@@ -0,0 +1,133 @@
+// This file is part of clutch.
+//
+// clutch is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// clutch is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with Foobar.  If not, see <http://www.gnu.org/licenses/>.
+
+package clutch
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+)
+
+// ErrUnknownFormat is returned by Parse when the document is well-formed but
+// is not a feed of a known format, e.g. an XML document whose root element is
+// not rss, feed, entry or rdf:RDF. The error of the parser of the format, if
+// any, is wrapped with it.
+var ErrUnknownFormat = errors.New("Data is not recognize as RSS 0.9x, " +
+	"RSS 1.0, RSS 2.0, Atom or JSON Feed")
+
+// ErrEmptyDocument is returned by Parse when the document is empty or only
+// holds white spaces
+var ErrEmptyDocument = errors.New("Data is empty")
+
+// SyntaxError is returned by Parse when the document is not well-formed XML
+// or JSON. The position is where the decoder stopped, just after the invalid
+// byte or at the end of a truncated document. The lines and the columns start
+// at 1, the columns and the offset count bytes.
+type SyntaxError struct {
+	Column   int
+	Err      error    // *xml.SyntaxError, *json.SyntaxError or io.ErrUnexpectedEOF
+	FeedType FeedType // FeedTypeUnknown when the error is before the root element
+	Line     int
+	Offset   int64
+}
+
+func (e *SyntaxError) Error() string {
+	return fmt.Sprintf("Syntax error at line %d, column %d (offset %d) : %s",
+		e.Line, e.Column, e.Offset, e.Err)
+}
+
+// Unwrap returns the error of the decoder
+func (e *SyntaxError) Unwrap() error {
+	return e.Err
+}
+
+// UnsupportedVersionError is returned by Parse when the format of the document
+// is known but its version is not, e.g. an rss element whose version attribute
+// is 3.0
+type UnsupportedVersionError struct {
+	FeedType FeedType
+	Version  string
+}
+
+func (e *UnsupportedVersionError) Error() string {
+	return fmt.Sprintf("Version '%s' of the %s format is not supported",
+		e.Version, e.FeedType)
+}
+
+// formatError wraps the error of the parser of a format, it is
+// ErrUnknownFormat for errors.Is
+type formatError struct {
+	err error
+}
+
+func (e *formatError) Error() string {
+	return ErrUnknownFormat.Error() + " : " + e.err.Error()
+}
+
+func (e *formatError) Is(target error) bool {
+	return target == ErrUnknownFormat
+}
+
+func (e *formatError) Unwrap() error {
+	return e.err
+}
+
+// trackReader counts the bytes read from r and keeps the first error of r, so
+// the errors of the reader are told apart from the errors of the decoders.
+// The offsets of the newlines are recorded too when trackLines is true, to
+// compute the position of a JSON error.
+type trackReader struct {
+	err        error
+	lines      []int64
+	offset     int64
+	r          io.Reader
+	trackLines bool
+}
+
+func (t *trackReader) Read(p []byte) (int, error) {
+	n, err := t.r.Read(p)
+
+	if t.trackLines {
+		for index, c := range p[:n] {
+			if c == '\n' {
+				t.lines = append(t.lines, t.offset+int64(index))
+			}
+		}
+	}
+	t.offset += int64(n)
+
+	if err != nil && err != io.EOF && t.err == nil {
+		t.err = err
+	}
+
+	return n, err
+}
+
+// position returns the line and the column of the byte at the offset, or of
+// the end of the document
+func (t *trackReader) position(offset int64) (int, int) {
+	line := sort.Search(len(t.lines), func(index int) bool {
+		return t.lines[index] >= offset
+	})
+
+	start := int64(0)
+	if line > 0 {
+		start = t.lines[line-1] + 1
+	}
+
+	return line + 1, int(offset-start) + 1
+}
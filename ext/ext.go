@@ -0,0 +1,119 @@
+// This file is part of clutch.
+//
+// clutch is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// clutch is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with Foobar.  If not, see <http://www.gnu.org/licenses/>.
+
+// Package ext keeps the elements of the namespaces which are not known by the
+// parsers, e.g. feedburner:origLink or slash:comments, and holds the registry
+// of the modules which decode the elements of a namespace into a typed value
+package ext
+
+import (
+	"encoding/xml"
+	"strings"
+)
+
+// Extensions are the elements of the unknown namespaces, by namespace then
+// by local name, in the order of the document
+type Extensions map[string]map[string][]Element
+
+// Element is an element of an extension, with its attributes and children
+type Element struct {
+	Attrs    []xml.Attr
+	Children Extensions
+	Name     xml.Name
+	Value    string // character data, trimmed
+}
+
+// Element returns the value into which the element must be decoded, it is
+// appended to the extensions. The map is created on the first element.
+func (e *Extensions) Element(name xml.Name) interface{} {
+	if *e == nil {
+		*e = Extensions{}
+	}
+
+	if (*e)[name.Space] == nil {
+		(*e)[name.Space] = map[string][]Element{}
+	}
+
+	elements := append((*e)[name.Space][name.Local], Element{Name: name})
+	(*e)[name.Space][name.Local] = elements
+	return &elements[len(elements)-1]
+}
+
+// Namespace returns the elements of the namespace by local name, nil if there
+// is none
+func (e Extensions) Namespace(space string) map[string][]Element {
+	return e[space]
+}
+
+// Get returns the elements of the namespace with the local name, nil if there
+// is none
+func (e Extensions) Get(space string, local string) []Element {
+	return e[space][local]
+}
+
+// Value returns the character data of the first element of the namespace with
+// the local name, the empty string if there is none
+func (e Extensions) Value(space string, local string) string {
+	if elements := e.Get(space, local); len(elements) > 0 {
+		return elements[0].Value
+	}
+
+	return ""
+}
+
+// Attr returns the value of the attribute with the local name, whatever its
+// namespace, and true if the element has it
+func (e *Element) Attr(local string) (string, bool) {
+	for _, a := range e.Attrs {
+		if a.Name.Local == local {
+			return a.Value, true
+		}
+	}
+
+	return "", false
+}
+
+// UnmarshalXML decodes the element with its attributes and all its children,
+// whatever their namespace. The namespace declarations are not kept.
+func (e *Element) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
+	e.Name = start.Name
+	for _, a := range start.Attr {
+		if a.Name.Space == "xmlns" || a.Name.Local == "xmlns" {
+			continue
+		}
+		e.Attrs = append(e.Attrs, a)
+	}
+
+	var value strings.Builder
+	for {
+		t, err := d.Token()
+		if err != nil {
+			return err
+		}
+
+		switch tok := t.(type) {
+		case xml.StartElement:
+			if err := d.DecodeElement(e.Children.Element(tok.Name),
+				&tok); err != nil {
+				return err
+			}
+		case xml.CharData:
+			value.Write(tok)
+		case xml.EndElement:
+			e.Value = strings.TrimSpace(value.String())
+			return nil
+		}
+	}
+}
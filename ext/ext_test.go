@@ -0,0 +1,93 @@
+// This file is part of clutch.
+//
+// clutch is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// clutch is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with Foobar.  If not, see <http://www.gnu.org/licenses/>.
+
+package ext
+
+import (
+	"encoding/xml"
+	"io/ioutil"
+	"reflect"
+	"testing"
+
+	"github.com/racam/clutch/internal/xmltest"
+)
+
+var prefix = "../testdata/ext/"
+
+const pricing = "http://example.com/ns/pricing"
+
+func TestElement(t *testing.T) {
+	filename := "unit_01_elements.xml"
+	data, err := ioutil.ReadFile(prefix + filename)
+	if err != nil {
+		t.Fatalf("[Ext][Unit][Element] file '%s' : is missing", prefix+filename)
+	}
+
+	var e Extensions
+	_, err = xmltest.Decode(data, e.Element)
+	if err != nil {
+		t.Fatalf("[Ext][Unit][Element] file '%s' : %s", filename, err)
+	}
+
+	if len(e) != 3 {
+		t.Errorf("[Ext][Unit][Element] expected 3 namespaces, actual %d",
+			len(e))
+	}
+
+	if v := e.Value("http://rssnamespace.org/feedburner/ext/1.0",
+		"origLink"); v != "http://example.org/1" {
+		t.Errorf("[Ext][Unit][Element] origLink : unexpected '%s'", v)
+	}
+
+	if v := e.Value("http://purl.org/rss/1.0/modules/slash/",
+		"comments"); v != "42" {
+		t.Errorf("[Ext][Unit][Element] comments : unexpected '%s'", v)
+	}
+
+	prices := e.Get(pricing, "price")
+	if len(prices) != 2 || len(e.Namespace(pricing)) != 1 {
+		t.Fatalf("[Ext][Unit][Element] price : unexpected %+v",
+			e.Namespace(pricing))
+	}
+
+	expected := Element{
+		Attrs: []xml.Attr{{Name: xml.Name{Local: "currency"}, Value: "EUR"}},
+		Children: Extensions{
+			pricing: {"amount": {{Name: xml.Name{Space: pricing,
+				Local: "amount"}, Value: "9.99"}}},
+			"": {"tax": {{Attrs: []xml.Attr{{Name: xml.Name{Local: "rate"},
+				Value: "20"}}, Name: xml.Name{Local: "tax"}}}},
+		},
+		Name: xml.Name{Space: pricing, Local: "price"},
+	}
+
+	if !reflect.DeepEqual(prices[0], expected) {
+		t.Errorf("[Ext][Unit][Element] expected %+v, actual %+v", expected,
+			prices[0])
+	}
+
+	if v, ok := prices[1].Attr("currency"); !ok || v != "USD" {
+		t.Errorf("[Ext][Unit][Element] currency : unexpected '%s'", v)
+	}
+
+	if _, ok := prices[1].Attr("rate"); ok {
+		t.Errorf("[Ext][Unit][Element] rate : unexpected attribute")
+	}
+
+	if e.Get("http://example.org/unknown", "price") != nil ||
+		e.Value(pricing, "unknown") != "" {
+		t.Errorf("[Ext][Unit][Element] unknown element : expected nothing")
+	}
+}
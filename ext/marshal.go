@@ -0,0 +1,53 @@
+// This file is part of clutch.
+//
+// clutch is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// clutch is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with Foobar.  If not, see <http://www.gnu.org/licenses/>.
+
+package ext
+
+import (
+	"sort"
+
+	"github.com/racam/clutch/internal/xmlenc"
+)
+
+// Write writes the elements, sorted by namespace then by local name so the
+// output does not depend on the order of the map
+func (e Extensions) Write(enc *xmlenc.Encoder) {
+	spaces := make([]string, 0, len(e))
+	for space := range e {
+		spaces = append(spaces, space)
+	}
+	sort.Strings(spaces)
+
+	for _, space := range spaces {
+		locals := make([]string, 0, len(e[space]))
+		for local := range e[space] {
+			locals = append(locals, local)
+		}
+		sort.Strings(locals)
+
+		for _, local := range locals {
+			for index := range e[space][local] {
+				e[space][local][index].write(enc)
+			}
+		}
+	}
+}
+
+func (e *Element) write(enc *xmlenc.Encoder) {
+	enc.Start(e.Name, e.Attrs...)
+	enc.Text(e.Value)
+	e.Children.Write(enc)
+	enc.End(e.Name)
+}
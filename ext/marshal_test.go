@@ -0,0 +1,64 @@
+// This file is part of clutch.
+//
+// clutch is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// clutch is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with Foobar.  If not, see <http://www.gnu.org/licenses/>.
+
+package ext
+
+import (
+	"bytes"
+	"encoding/xml"
+	"strings"
+	"testing"
+
+	"github.com/racam/clutch/internal/xmlenc"
+)
+
+func TestWrite(t *testing.T) {
+	e := Extensions{}
+	e.Element(xml.Name{Space: "http://b.example.org/", Local: "b"})
+	v := e.Element(xml.Name{Space: "http://a.example.org/", Local: "a"})
+	v.(*Element).Value = "1 & 2"
+	v.(*Element).Attrs = []xml.Attr{{Name: xml.Name{Local: "x"}, Value: "y"}}
+	v.(*Element).Children.Element(xml.Name{Space: "http://a.example.org/",
+		Local: "child"})
+
+	var buf bytes.Buffer
+	enc := xmlenc.NewEncoder(&buf)
+	e.Write(enc)
+	if err := enc.Close(); err != nil {
+		t.Fatalf("[Ext][Marshal] %s", err)
+	}
+
+	a := strings.Index(buf.String(), `<a xmlns="http://a.example.org/" x="y">1 &amp; 2`)
+	child := strings.Index(buf.String(), `<child xmlns="http://a.example.org/">`)
+	b := strings.Index(buf.String(), `<b xmlns="http://b.example.org/">`)
+	if a < 0 || child < a || b < child {
+		t.Errorf("[Ext][Marshal] unexpected\n%s", buf.String())
+	}
+}
+
+func TestModulesWrite(t *testing.T) {
+	var buf bytes.Buffer
+	enc := xmlenc.NewEncoder(&buf)
+	Modules{pricing: &prices{Discount: "10"}}.Write(enc)
+	if err := enc.Close(); err != nil {
+		t.Fatalf("[Ext][Marshal] %s", err)
+	}
+
+	expected := `<discount xmlns="http://example.com/ns/pricing">10</discount>`
+	if !strings.Contains(buf.String(), expected) {
+		t.Errorf("[Ext][Marshal] expected %s, actual %s", expected,
+			buf.String())
+	}
+}
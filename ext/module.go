@@ -0,0 +1,162 @@
+// This file is part of clutch.
+//
+// clutch is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// clutch is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with Foobar.  If not, see <http://www.gnu.org/licenses/>.
+
+package ext
+
+import (
+	"encoding/xml"
+	"sort"
+	"sync"
+
+	"github.com/racam/clutch/internal/xmlenc"
+)
+
+// Module is the typed value of the elements of a namespace, e.g.
+// dc.DublinCore. Element returns the value into which an element of the
+// namespace must be decoded, nil if the module does not know it.
+type Module interface {
+	Element(name xml.Name) interface{}
+}
+
+// NewModule returns a new empty module. It is called on the first element of
+// the namespace of every channel, item, feed or entry.
+type NewModule func() Module
+
+// writer is implemented by the modules which can be written back, like the
+// built-in ones
+type writer interface {
+	Write(enc *xmlenc.Encoder)
+}
+
+var (
+	registry      = map[string]NewModule{}
+	registryMutex sync.RWMutex
+	// overridden holds the namespaces passed to Register, their elements are
+	// not decoded into the typed fields of the parents
+	overridden = map[string]bool{}
+)
+
+// Register registers the module of the namespace, the parsers decode its
+// elements into the module instead of keeping them as extensions. It replaces
+// the module already registered for the namespace, a nil newModule removes it.
+// It also overrides the built-in modules, kept in the typed fields of the
+// parents (e.g. rss.Item.DublinCore or ITunes) : their elements are decoded
+// into the Modules of the parents, or kept as extensions once removed.
+func Register(space string, newModule NewModule) {
+	registryMutex.Lock()
+	defer registryMutex.Unlock()
+
+	overridden[space] = true
+	if newModule == nil {
+		delete(registry, space)
+		return
+	}
+	registry[space] = newModule
+}
+
+// RegisterBuiltin registers the module of a namespace kept in the typed fields
+// of the parents, for the formats without such a field. The built-in modules
+// (Dublin Core, content, Media RSS) register themselves this way. It cancels
+// the overriding of the namespace by Register.
+func RegisterBuiltin(space string, newModule NewModule) {
+	registryMutex.Lock()
+	defer registryMutex.Unlock()
+
+	delete(overridden, space)
+	if newModule == nil {
+		delete(registry, space)
+		return
+	}
+	registry[space] = newModule
+}
+
+// Registered returns the function creating the module of the namespace, nil
+// if none is registered
+func Registered(space string) NewModule {
+	registryMutex.RLock()
+	defer registryMutex.RUnlock()
+
+	return registry[space]
+}
+
+// isOverridden returns true if the namespace has been passed to Register
+func isOverridden(space string) bool {
+	registryMutex.RLock()
+	defer registryMutex.RUnlock()
+
+	return overridden[space]
+}
+
+// Modules are the registered modules of a channel, item, feed or entry, by
+// namespace
+type Modules map[string]Module
+
+// Element returns the value into which the element must be decoded. The module
+// of its namespace is the one returned by fields, a module kept in a field of
+// the parent (nil when there is none), unless the namespace is overridden by
+// Register, or else the registered one, created and kept in the map on the
+// first element. nil is returned when no module knows the element, it should
+// then be kept as an extension.
+func (m *Modules) Element(name xml.Name,
+	fields func(space string) Module) interface{} {
+	if fields != nil && !isOverridden(name.Space) {
+		if module := fields(name.Space); module != nil {
+			return module.Element(name)
+		}
+	}
+
+	if module, ok := (*m)[name.Space]; ok {
+		return module.Element(name)
+	}
+
+	newModule := Registered(name.Space)
+	if newModule == nil {
+		return nil
+	}
+
+	module := newModule()
+	v := module.Element(name)
+	if v == nil {
+		return nil
+	}
+
+	if *m == nil {
+		*m = Modules{}
+	}
+	(*m)[name.Space] = module
+	return v
+}
+
+// Get returns the module of the namespace, nil if the document has no element
+// of it. The caller asserts its type, e.g. m.Get(dc.Namespace).(*dc.DublinCore)
+func (m Modules) Get(space string) Module {
+	return m[space]
+}
+
+// Write writes the modules which can be written, like the built-in ones,
+// sorted by namespace
+func (m Modules) Write(enc *xmlenc.Encoder) {
+	spaces := make([]string, 0, len(m))
+	for space := range m {
+		spaces = append(spaces, space)
+	}
+	sort.Strings(spaces)
+
+	for _, space := range spaces {
+		if w, ok := m[space].(writer); ok {
+			w.Write(enc)
+		}
+	}
+}
@@ -0,0 +1,169 @@
+// This file is part of clutch.
+//
+// clutch is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// clutch is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with Foobar.  If not, see <http://www.gnu.org/licenses/>.
+package ext
+
+import (
+	"bytes"
+	"encoding/xml"
+	"io"
+	"io/ioutil"
+	"reflect"
+	"testing"
+
+	"github.com/racam/clutch/internal/xmldec"
+	"github.com/racam/clutch/internal/xmlenc"
+)
+
+// price is the element of the pricing module of the tests
+type price struct {
+	Currency string `xml:"currency,attr"`
+	Value    string `xml:",chardata"`
+}
+
+// prices is the pricing module of the tests
+type prices struct {
+	Discount string
+	Prices   []price
+}
+
+func (p *prices) Element(name xml.Name) interface{} {
+	if name.Space != pricing {
+		return nil
+	}
+
+	switch name.Local {
+	case "discount":
+		return &p.Discount
+	case "price":
+		p.Prices = append(p.Prices, price{})
+		return &p.Prices[len(p.Prices)-1]
+	}
+	return nil
+}
+
+func (p *prices) Write(enc *xmlenc.Encoder) {
+	name := xml.Name{Space: pricing, Local: "discount"}
+	enc.Start(name)
+	enc.Text(p.Discount)
+	enc.End(name)
+}
+
+// decodeModules decodes the children of the root element of the document into
+// the modules then into the extensions, like the parsers of the formats do
+func decodeModules(data []byte, fields func(space string) Module) (Modules,
+	Extensions, error) {
+	d := xmldec.NewDecoder(bytes.NewReader(data))
+	if _, err := xmldec.RootElement(d); err != nil {
+		return nil, nil, err
+	}
+
+	var modules Modules
+	var extensions Extensions
+	for {
+		child, err := xmldec.NextChild(d)
+		if err == io.EOF {
+			return modules, extensions, nil
+		} else if err != nil {
+			return nil, nil, err
+		}
+
+		v := modules.Element(child.Name, fields)
+		if v == nil {
+			v = extensions.Element(child.Name)
+		}
+
+		if err := d.DecodeElement(v, &child); err != nil {
+			return nil, nil, err
+		}
+	}
+}
+
+func TestModules(t *testing.T) {
+	filename := "unit_02_modules.xml"
+	data, err := ioutil.ReadFile(prefix + filename)
+	if err != nil {
+		t.Fatalf("[Ext][Unit][Modules] file '%s' : is missing",
+			prefix+filename)
+	}
+
+	m, e, err := decodeModules(data, nil)
+	if err != nil {
+		t.Fatalf("[Ext][Unit][Modules] file '%s' : %s", filename, err)
+	}
+
+	if m != nil || len(e.Namespace(pricing)) != 3 {
+		t.Errorf("[Ext][Unit][Modules] not registered : unexpected modules "+
+			"%+v or extensions %+v", m, e)
+	}
+
+	Register(pricing, func() Module { return &prices{} })
+	// removes the registration and the overriding of the namespace
+	defer RegisterBuiltin(pricing, nil)
+
+	m, e, err = decodeModules(data, nil)
+	if err != nil {
+		t.Fatalf("[Ext][Unit][Modules] file '%s' : %s", filename, err)
+	}
+
+	expected := &prices{Discount: "10", Prices: []price{
+		{Currency: "EUR", Value: "9.99"}, {Currency: "USD", Value: "11.99"}}}
+	if !reflect.DeepEqual(m.Get(pricing), expected) {
+		t.Errorf("[Ext][Unit][Modules] expected %+v, actual %+v", expected,
+			m.Get(pricing))
+	}
+
+	if e.Value(pricing, "unknown") != "unknown" || len(e) != 2 {
+		t.Errorf("[Ext][Unit][Modules] unknown element : expected an "+
+			"extension, actual %+v", e)
+	}
+
+	// a module kept in a field of the parent is not used once the namespace
+	// is registered with Register
+	var field prices
+	fields := func(space string) Module {
+		if space == pricing {
+			return &field
+		}
+		return nil
+	}
+
+	m, _, err = decodeModules(data, fields)
+	if err != nil {
+		t.Fatalf("[Ext][Unit][Modules] file '%s' : %s", filename, err)
+	}
+
+	if !reflect.DeepEqual(m.Get(pricing), expected) ||
+		!reflect.DeepEqual(field, prices{}) {
+		t.Errorf("[Ext][Unit][Modules] overridden field : expected %+v, "+
+			"actual %+v and field %+v", expected, m.Get(pricing), field)
+	}
+
+	// it is preferred to a built-in registration
+	RegisterBuiltin(pricing, func() Module { return &prices{} })
+
+	m, _, err = decodeModules(data, fields)
+	if err != nil {
+		t.Fatalf("[Ext][Unit][Modules] file '%s' : %s", filename, err)
+	}
+
+	if m != nil || !reflect.DeepEqual(&field, expected) {
+		t.Errorf("[Ext][Unit][Modules] field : expected %+v, actual %+v "+
+			"and modules %+v", expected, field, m)
+	}
+
+	if Registered("http://example.org/unknown") != nil {
+		t.Errorf("[Ext][Unit][Modules] unknown namespace : expected no module")
+	}
+}
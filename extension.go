@@ -0,0 +1,29 @@
+// This file is part of clutch.
+//
+// clutch is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// clutch is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with Foobar.  If not, see <http://www.gnu.org/licenses/>.
+package clutch
+
+import "github.com/racam/clutch/ext"
+
+// RegisterExtension registers the module of a namespace, e.g. a Go structure
+// with an Element method returning the field of each element. The RSS, RDF and
+// Atom parsers decode the elements of the namespace into a new module for
+// every channel, item, feed and entry, available in the Modules of Feed and
+// Entry, instead of keeping them in the Extensions. Registering the namespace
+// of a built-in module, like Dublin Core or iTunes, replaces it : its elements
+// are no longer decoded into the typed fields, a nil newModule keeps them as
+// extensions. It is a shortcut for ext.Register.
+func RegisterExtension(space string, newModule ext.NewModule) {
+	ext.Register(space, newModule)
+}
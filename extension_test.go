@@ -0,0 +1,135 @@
+// This file is part of clutch.
+//
+// clutch is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// clutch is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with Foobar.  If not, see <http://www.gnu.org/licenses/>.
+package clutch
+
+import (
+	"encoding/xml"
+	"testing"
+
+	"github.com/racam/clutch/content"
+	"github.com/racam/clutch/dc"
+	"github.com/racam/clutch/ext"
+)
+
+const pricing = "http://example.com/ns/pricing"
+
+// price is the pricing module of the tests
+type price struct {
+	Currency string `xml:"currency,attr"`
+	Value    string `xml:",chardata"`
+}
+
+func (p *price) Element(name xml.Name) interface{} {
+	if name == (xml.Name{Space: pricing, Local: "price"}) {
+		return p
+	}
+
+	return nil
+}
+
+func TestRegisterExtension(t *testing.T) {
+	RegisterExtension(pricing, func() ext.Module { return &price{} })
+	defer RegisterExtension(pricing, nil)
+
+	var files = []string{"testdata/rss/unit_14_parse_modules.xml",
+		"testdata/atom/unit_32_parse_modules.xml"}
+
+	for _, filename := range files {
+		f := parseFile(t, filename)
+
+		e := f.Entry[0]
+		p, ok := e.Modules.Get(pricing).(*price)
+		if !ok || p.Currency != "EUR" || p.Value != "9.99" {
+			t.Errorf("[Clutch][Unit][Extension] file '%s' : unexpected "+
+				"modules %+v", filename, e.Modules)
+		}
+
+		if e.Extensions.Value(pricing, "unknown") != "unknown" ||
+			e.Extensions.Get(pricing, "price") != nil {
+			t.Errorf("[Clutch][Unit][Extension] file '%s' : unexpected "+
+				"extensions %+v", filename, e.Extensions)
+		}
+	}
+
+	f := parseFile(t, "testdata/rss/unit_14_parse_modules.xml")
+	if *f.Entry[0].Content.Value != "<p>Item</p>" ||
+		len(f.Entry[0].Modules) != 1 {
+		t.Errorf("[Clutch][Unit][Extension] Item : unexpected content '%s' or "+
+			"modules %+v", *f.Entry[0].Content.Value, f.Entry[0].Modules)
+	}
+
+	c, ok := f.Modules.Get(content.Namespace).(*content.Encoded)
+	if !ok || *c != "<p>Channel</p>" {
+		t.Errorf("[Clutch][Unit][Extension] Channel : unexpected modules %+v",
+			f.Modules)
+	}
+
+	f = parseFile(t, "testdata/atom/unit_32_parse_modules.xml")
+	d, ok := f.Atom.Entry[0].Source.Modules.Get(dc.Namespace).(*dc.DublinCore)
+	if !ok || len(d.Creator) != 1 || d.Creator[0] != "John Doe" {
+		t.Errorf("[Clutch][Unit][Extension] Source : unexpected modules %+v",
+			f.Atom.Entry[0].Source.Modules)
+	}
+
+	// without the registration, the elements are extensions again
+	RegisterExtension(pricing, nil)
+	f = parseFile(t, "testdata/atom/unit_32_parse_modules.xml")
+	if f.Entry[0].Modules != nil ||
+		len(f.Entry[0].Extensions.Get(pricing, "price")) != 1 {
+		t.Errorf("[Clutch][Unit][Extension] unregistered : unexpected "+
+			"modules %+v", f.Entry[0].Modules)
+	}
+}
+
+// creators is a replacement of the Dublin Core module which keeps the
+// creators only
+type creators struct {
+	Creator []string
+}
+
+func (c *creators) Element(name xml.Name) interface{} {
+	if name == (xml.Name{Space: dc.Namespace, Local: "creator"}) {
+		c.Creator = append(c.Creator, "")
+		return &c.Creator[len(c.Creator)-1]
+	}
+
+	return nil
+}
+
+func TestRegisterExtensionBuiltin(t *testing.T) {
+	builtin := ext.Registered(dc.Namespace)
+	defer ext.RegisterBuiltin(dc.Namespace, builtin)
+
+	filename := "testdata/rss/unit_09_parse_dublincore.xml"
+	RegisterExtension(dc.Namespace, func() ext.Module { return &creators{} })
+
+	f := parseFile(t, filename)
+	c, ok := f.Entry[0].Modules.Get(dc.Namespace).(*creators)
+	if !ok || len(c.Creator) != 1 || c.Creator[0] != "Jane Doe" ||
+		len(f.RSS.Channel.Item[0].DublinCore.Creator) != 0 {
+		t.Errorf("[Clutch][Unit][Extension] replaced : unexpected modules %+v",
+			f.Entry[0].Modules)
+	}
+
+	// without the registration, the elements are extensions
+	RegisterExtension(dc.Namespace, nil)
+	f = parseFile(t, filename)
+	if f.Entry[0].Modules != nil ||
+		len(f.Entry[0].Extensions.Get(dc.Namespace, "creator")) != 1 ||
+		len(f.RSS.Channel.Item[0].DublinCore.Creator) != 0 {
+		t.Errorf("[Clutch][Unit][Extension] removed : unexpected modules %+v "+
+			"or extensions %+v", f.Entry[0].Modules, f.Entry[0].Extensions)
+	}
+}
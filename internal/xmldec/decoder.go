@@ -0,0 +1,137 @@
+// This file is part of clutch.
+//
+// clutch is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// clutch is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with Foobar.  If not, see <http://www.gnu.org/licenses/>.
+
+// Package xmldec reads the feeds of the rss, atom and rdf packages and the
+// elements of their modules. The documents are decoded in a single pass : the
+// root element is read first to choose the structure, then the decoding
+// continues from it.
+package xmldec
+
+import (
+	"encoding/xml"
+	"errors"
+	"io"
+)
+
+// NewDecoder returns a decoder which reads the XML document from r without
+// buffering the whole document
+func NewDecoder(r io.Reader) *xml.Decoder {
+	return xml.NewDecoder(r)
+}
+
+// RootElement reads the prolog of the document, i.e. the XML declaration, the
+// comments and the processing instructions, and returns the root element. The
+// decoder is then ready to decode the root element with DecodeElement.
+func RootElement(d *xml.Decoder) (xml.StartElement, error) {
+	for {
+		t, err := d.Token()
+		if err == io.EOF {
+			return xml.StartElement{}, errors.New("XML document has no root " +
+				"element")
+		}
+		if err != nil {
+			return xml.StartElement{}, err
+		}
+
+		if start, ok := t.(xml.StartElement); ok {
+			return start, nil
+		}
+	}
+}
+
+// NextChild reads the tokens until the next child element of the current
+// element and returns it, the caller decodes or skips the child before the
+// next call. io.EOF is returned at the end of the current element.
+func NextChild(d *xml.Decoder) (xml.StartElement, error) {
+	for {
+		t, err := d.Token()
+		if err != nil {
+			return xml.StartElement{}, err
+		}
+
+		switch tok := t.(type) {
+		case xml.StartElement:
+			return tok, nil
+		case xml.EndElement:
+			return xml.StartElement{}, io.EOF
+		}
+	}
+}
+
+// DecodeChildren decodes the children of the current element until its end.
+// The children whose namespace is accepted by inNamespace are decoded into
+// the value returned by field for their local name, the other ones into the
+// value returned by other, which may be nil. Unlike the struct tags of
+// encoding/xml, an element of another namespace with the name of a field,
+// e.g. dc:title, is not taken for the field. The children without a value
+// are skipped.
+func DecodeChildren(d *xml.Decoder, inNamespace func(space string) bool,
+	field func(local string) interface{},
+	other func(name xml.Name) interface{}) error {
+	for {
+		child, err := NextChild(d)
+		if err == io.EOF {
+			return nil
+		} else if err != nil {
+			return err
+		}
+
+		var v interface{}
+		if inNamespace(child.Name.Space) {
+			v = field(child.Name.Local)
+		} else if other != nil {
+			v = other(child.Name)
+		}
+
+		if v == nil {
+			if err := d.Skip(); err != nil {
+				return err
+			}
+			continue
+		}
+
+		if err := d.DecodeElement(v, &child); err != nil {
+			return err
+		}
+	}
+}
+
+// InNamespace returns the predicate of DecodeChildren which accepts the given
+// namespaces
+func InNamespace(spaces ...string) func(space string) bool {
+	return func(space string) bool {
+		for _, s := range spaces {
+			if space == s {
+				return true
+			}
+		}
+		return false
+	}
+}
+
+// Attr returns the value of the attribute of the element with the given local
+// name, whatever its namespace, like the untagged attributes of the xml
+// package. The last one wins when the attribute is duplicated.
+func Attr(start xml.StartElement, local string) (string, bool) {
+	value, found := "", false
+
+	for _, a := range start.Attr {
+		if a.Name.Local == local {
+			value, found = a.Value, true
+		}
+	}
+
+	return value, found
+}
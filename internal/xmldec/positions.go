@@ -0,0 +1,130 @@
+// This file is part of clutch.
+//
+// clutch is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// clutch is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with Foobar.  If not, see <http://www.gnu.org/licenses/>.
+
+package xmldec
+
+import (
+	"encoding/xml"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// Positions records the position and the number of the elements of a
+// document. Only the elements in the namespace of the root element are
+// recorded, the extensions are skipped. The elements are identified by their
+// path like /feed/entry[3]/author[1]/name, a step without index is the first
+// element with this name.
+type Positions struct {
+	counts    map[string]int
+	positions map[string][2]int
+}
+
+// frame is an element being read
+type frame struct {
+	children map[string]int
+	key      string
+}
+
+// Record reads the whole document from r and records its elements. The
+// position of an element is the end of its start tag.
+func Record(r io.Reader) (*Positions, error) {
+	p := Positions{counts: map[string]int{}, positions: map[string][2]int{}}
+	d := NewDecoder(r)
+
+	root, err := RootElement(d)
+	if err != nil {
+		return nil, err
+	}
+
+	line, column := d.InputPos()
+	key := "/" + root.Name.Local + "[1]"
+	p.positions[key] = [2]int{line, column}
+	stack := []frame{{children: map[string]int{}, key: key}}
+
+	for len(stack) > 0 {
+		t, err := d.Token()
+		if err != nil {
+			return nil, err
+		}
+
+		switch tok := t.(type) {
+		case xml.StartElement:
+			if tok.Name.Space != root.Name.Space {
+				if err := d.Skip(); err != nil {
+					return nil, err
+				}
+				continue
+			}
+
+			parent := stack[len(stack)-1]
+			parent.children[tok.Name.Local]++
+			key := parent.key + "/" + tok.Name.Local + "[" +
+				strconv.Itoa(parent.children[tok.Name.Local]) + "]"
+
+			line, column := d.InputPos()
+			p.positions[key] = [2]int{line, column}
+			stack = append(stack, frame{children: map[string]int{}, key: key})
+		case xml.EndElement:
+			current := stack[len(stack)-1]
+			for local, n := range current.children {
+				p.counts[current.key+"/"+local] = n
+			}
+			stack = stack[:len(stack)-1]
+		}
+	}
+
+	return &p, nil
+}
+
+// Position returns the line and the column of the element at the path, or of
+// its closest recorded parent. It returns 0, 0 if there is none.
+func (p *Positions) Position(path string) (int, int) {
+	if p == nil {
+		return 0, 0
+	}
+
+	key := normalize(path)
+	for key != "" {
+		if pos, ok := p.positions[key]; ok {
+			return pos[0], pos[1]
+		}
+		key = key[:strings.LastIndex(key, "/")]
+	}
+
+	return 0, 0
+}
+
+// Count returns the number of children named local of the element at the
+// path. It returns -1 if the positions are unknown.
+func (p *Positions) Count(path string, local string) int {
+	if p == nil {
+		return -1
+	}
+
+	return p.counts[normalize(path)+"/"+local]
+}
+
+// normalize adds the index 1 to the steps of the path without index
+func normalize(path string) string {
+	steps := strings.Split(strings.TrimPrefix(path, "/"), "/")
+	for index, s := range steps {
+		if !strings.HasSuffix(s, "]") {
+			steps[index] = s + "[1]"
+		}
+	}
+
+	return "/" + strings.Join(steps, "/")
+}
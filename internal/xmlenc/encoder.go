@@ -0,0 +1,191 @@
+// This file is part of clutch.
+//
+// clutch is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// clutch is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with Foobar.  If not, see <http://www.gnu.org/licenses/>.
+
+// Package xmlenc writes the feeds of the rss and atom packages. The element
+// names are given explicitly so the output does not depend on the struct tags
+// used for the parsing.
+package xmlenc
+
+import (
+	"encoding/xml"
+	"io"
+	"io/ioutil"
+)
+
+// NamespaceXML is the namespace bound to the xml prefix, used by the xml:base
+// and xml:lang attributes
+const NamespaceXML string = "http://www.w3.org/XML/1998/namespace"
+
+// Encoder writes an XML document token by token. The first error stops the
+// writing and is returned by Close, so the callers do not need to check every
+// call. The elements of a namespace bound to a prefix, see Bind, are written
+// with it, the ones of the other namespaces declare it as the default one.
+type Encoder struct {
+	e   *xml.Encoder
+	w   io.Writer
+	err error
+
+	// declarations are the prefixes bound by Bind, not written yet
+	declarations []xml.Attr
+	prefixes     map[string]string
+	// used collects the namespaces written when Bind looks for them
+	used map[string]bool
+}
+
+// Prefix is a prefix and the namespace bound to it
+type Prefix struct {
+	Name  string
+	Space string
+}
+
+// NewEncoder returns an Encoder that writes the XML declaration and indented
+// elements to w
+func NewEncoder(w io.Writer) *Encoder {
+	e := xml.NewEncoder(w)
+	e.Indent("", "  ")
+
+	enc := &Encoder{e: e, w: w}
+	enc.Raw(xml.Header)
+	return enc
+}
+
+// Bind binds the prefixes of the namespaces used by the elements write writes
+// for the rest of the document. Their declarations are written on the next
+// element started, the root element, so the elements and the attributes of
+// these namespaces are written with their prefix instead of redeclaring the
+// default namespace each time. The prefixes of the namespaces not used are not
+// declared : write is called a first time, writing nowhere, to find them.
+func (enc *Encoder) Bind(write func(enc *Encoder), prefixes ...Prefix) {
+	discard := &Encoder{e: xml.NewEncoder(ioutil.Discard), w: ioutil.Discard,
+		used: map[string]bool{}}
+	write(discard)
+
+	for _, p := range prefixes {
+		if !discard.used[p.Space] {
+			continue
+		}
+
+		if enc.prefixes == nil {
+			enc.prefixes = make(map[string]string)
+		}
+		enc.prefixes[p.Space] = p.Name
+		enc.declarations = append(enc.declarations,
+			Attr("xmlns:"+p.Name, p.Space))
+	}
+}
+
+// prefixed returns the name with the prefix bound to its namespace, or the
+// name as it is if the namespace is not bound
+func (enc *Encoder) prefixed(name xml.Name) xml.Name {
+	if prefix, ok := enc.prefixes[name.Space]; ok {
+		return xml.Name{Local: prefix + ":" + name.Local}
+	}
+
+	return name
+}
+
+// Start opens an element, the attributes with an empty value are omitted
+func (enc *Encoder) Start(name xml.Name, attrs ...xml.Attr) {
+	if enc.err != nil {
+		return
+	}
+
+	if enc.used != nil {
+		enc.used[name.Space] = true
+	}
+
+	start := xml.StartElement{Name: enc.prefixed(name)}
+	for _, a := range attrs {
+		if a.Value != "" {
+			if enc.used != nil {
+				enc.used[a.Name.Space] = true
+			}
+			a.Name = enc.prefixed(a.Name)
+			start.Attr = append(start.Attr, a)
+		}
+	}
+	start.Attr = append(start.Attr, enc.declarations...)
+	enc.declarations = nil
+
+	enc.err = enc.e.EncodeToken(start)
+}
+
+// End closes the element opened by the matching Start
+func (enc *Encoder) End(name xml.Name) {
+	if enc.err != nil {
+		return
+	}
+
+	enc.err = enc.e.EncodeToken(xml.EndElement{Name: enc.prefixed(name)})
+}
+
+// Text writes escaped character data
+func (enc *Encoder) Text(s string) {
+	if enc.err != nil || s == "" {
+		return
+	}
+
+	enc.err = enc.e.EncodeToken(xml.CharData(s))
+}
+
+// Raw writes s without escaping it, s MUST be well-formed XML
+func (enc *Encoder) Raw(s string) {
+	if enc.err != nil || s == "" {
+		return
+	}
+
+	if enc.err = enc.e.Flush(); enc.err != nil {
+		return
+	}
+
+	_, enc.err = io.WriteString(enc.w, s)
+}
+
+// Element writes an element which only contains escaped character data. It
+// is omitted if the value is empty.
+func (enc *Encoder) Element(name string, value string) {
+	if value == "" {
+		return
+	}
+
+	n := xml.Name{Local: name}
+	enc.Start(n)
+	enc.Text(value)
+	enc.End(n)
+}
+
+// Close flushes the document and returns the first error
+func (enc *Encoder) Close() error {
+	if enc.err != nil {
+		return enc.err
+	}
+
+	enc.err = enc.e.Flush()
+	if enc.err == nil {
+		_, enc.err = io.WriteString(enc.w, "\n")
+	}
+
+	return enc.err
+}
+
+// Attr returns an attribute without namespace
+func Attr(name string, value string) xml.Attr {
+	return xml.Attr{Name: xml.Name{Local: name}, Value: value}
+}
+
+// XMLAttr returns an attribute of the xml namespace, i.e. xml:base or xml:lang
+func XMLAttr(name string, value string) xml.Attr {
+	return xml.Attr{Name: xml.Name{Space: NamespaceXML, Local: name}, Value: value}
+}
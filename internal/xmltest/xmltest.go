@@ -0,0 +1,59 @@
+// This file is part of clutch.
+//
+// clutch is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// clutch is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with Foobar.  If not, see <http://www.gnu.org/licenses/>.
+
+// Package xmltest holds the helpers shared by the tests of the modules
+package xmltest
+
+import (
+	"bytes"
+	"encoding/xml"
+	"io"
+
+	"github.com/racam/clutch/internal/xmldec"
+)
+
+// Decode decodes the children of the root element of the document like the
+// parsers of the formats do, into the value returned by element for their
+// name. It returns the number of children skipped because element returned
+// nil for them.
+func Decode(data []byte, element func(name xml.Name) interface{}) (int,
+	error) {
+	d := xmldec.NewDecoder(bytes.NewReader(data))
+	if _, err := xmldec.RootElement(d); err != nil {
+		return 0, err
+	}
+
+	skipped := 0
+	for {
+		child, err := xmldec.NextChild(d)
+		if err == io.EOF {
+			return skipped, nil
+		} else if err != nil {
+			return 0, err
+		}
+
+		v := element(child.Name)
+		if v == nil {
+			skipped++
+			err = d.Skip()
+		} else {
+			err = d.DecodeElement(v, &child)
+		}
+
+		if err != nil {
+			return 0, err
+		}
+	}
+}
@@ -0,0 +1,196 @@
+// This file is part of clutch.
+//
+// clutch is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// clutch is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with Foobar.  If not, see <http://www.gnu.org/licenses/>.
+
+package itunes
+
+import (
+	"encoding/xml"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/racam/clutch/internal/xmldec"
+)
+
+// inITunes returns true for the namespace of the iTunes elements
+var inITunes = xmldec.InNamespace(Namespace)
+
+// Element returns the value into which the element, a child of the channel,
+// must be decoded, nil if it is not an iTunes element of the channel
+func (c *Channel) Element(name xml.Name) interface{} {
+	if name.Space != Namespace {
+		return nil
+	}
+
+	switch name.Local {
+	case "author":
+		return &c.Author
+	case "block":
+		return (*block)(&c.Block)
+	case "category":
+		c.Categories = append(c.Categories, Category{})
+		return &c.Categories[len(c.Categories)-1]
+	case "explicit":
+		return &explicit{&c.Explicit}
+	case "image":
+		return (*image)(&c.Image)
+	case "owner":
+		return &c.Owner
+	}
+
+	return nil
+}
+
+// Element returns the value into which the element, a child of an item, must
+// be decoded, nil if it is not an iTunes element of the item
+func (i *Item) Element(name xml.Name) interface{} {
+	if name.Space != Namespace {
+		return nil
+	}
+
+	switch name.Local {
+	case "author":
+		return &i.Author
+	case "block":
+		return (*block)(&i.Block)
+	case "duration":
+		return (*duration)(&i.Duration)
+	case "episode":
+		return &number{&i.Episode, &i.InvalidEpisode}
+	case "episodeType":
+		return &i.EpisodeType
+	case "explicit":
+		return &explicit{&i.Explicit}
+	case "image":
+		return (*image)(&i.Image)
+	case "season":
+		return &number{&i.Season, &i.InvalidSeason}
+	}
+
+	return nil
+}
+
+// UnmarshalXML decodes the itunes:category element and its sub-categories
+func (c *Category) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
+	for _, a := range start.Attr {
+		if a.Name.Local == "text" {
+			c.Text = a.Value
+		}
+	}
+
+	return xmldec.DecodeChildren(d, inITunes, func(local string) interface{} {
+		if local == "category" {
+			c.Categories = append(c.Categories, Category{})
+			return &c.Categories[len(c.Categories)-1]
+		}
+		return nil
+	}, nil)
+}
+
+// UnmarshalXML decodes the itunes:owner element, see xmldec.DecodeChildren
+func (o *Owner) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
+	return xmldec.DecodeChildren(d, inITunes, func(local string) interface{} {
+		switch local {
+		case "email":
+			return &o.Email
+		case "name":
+			return &o.Name
+		}
+		return nil
+	}, nil)
+}
+
+// text decodes the character data of an element, trimmed
+func text(d *xml.Decoder, start xml.StartElement) (string, error) {
+	var value string
+	err := d.DecodeElement(&value, &start)
+	return strings.TrimSpace(value), err
+}
+
+// block is the itunes:block element, only yes blocks the podcast
+type block bool
+
+// UnmarshalXML decodes the itunes:block element, whatever its case
+func (b *block) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
+	value, err := text(d, start)
+	*b = block(strings.EqualFold(value, "yes"))
+	return err
+}
+
+// duration is the itunes:duration element, see ParseDuration
+type duration time.Duration
+
+// UnmarshalXML decodes the itunes:duration element, a duration which is not
+// valid is decoded as 0
+func (t *duration) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
+	value, err := text(d, start)
+	res, _ := ParseDuration(value)
+	*t = duration(res)
+	return err
+}
+
+// explicit is the itunes:explicit element, see ParseExplicit
+type explicit struct {
+	value **bool
+}
+
+// UnmarshalXML decodes the itunes:explicit element, a value which is not
+// known is decoded as nil
+func (e *explicit) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
+	value, err := text(d, start)
+
+	*e.value = nil
+	if res, perr := ParseExplicit(value); perr == nil {
+		*e.value = &res
+	}
+
+	return err
+}
+
+// image is the itunes:image element, its URL is the href attribute
+type image string
+
+// UnmarshalXML decodes the itunes:image element
+func (i *image) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
+	for _, a := range start.Attr {
+		if a.Name.Local == "href" {
+			*i = image(a.Value)
+		}
+	}
+
+	return d.Skip()
+}
+
+// number is an integer element, a value which is not an integer is decoded
+// as 0 and kept in invalid for the check step
+type number struct {
+	value   *int
+	invalid *string
+}
+
+// UnmarshalXML decodes an integer element
+func (n *number) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
+	value, err := text(d, start)
+	if err != nil {
+		return err
+	}
+
+	i, err := strconv.Atoi(value)
+	if err != nil && value != "" {
+		*n.invalid = value
+	}
+	*n.value = i
+	return nil
+}
@@ -0,0 +1,115 @@
+// This file is part of clutch.
+//
+// clutch is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// clutch is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with Foobar.  If not, see <http://www.gnu.org/licenses/>.
+
+// Package itunes please Refer to https://help.apple.com/itc/podcasts_connect/#/itcb54353390
+package itunes
+
+import (
+	"errors"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Namespace is the namespace of the podcast elements of Apple Podcasts
+// source : https://help.apple.com/itc/podcasts_connect/#/itcb54353390
+const Namespace string = "http://www.itunes.com/dtds/podcast-1.0.dtd"
+
+// Channel is an iTunes structure like describe in
+// https://help.apple.com/itc/podcasts_connect/#/itcb54353390
+// It holds the iTunes elements of the channel of a podcast.
+type Channel struct {
+	Author     string
+	Block      bool // yes removes the podcast from Apple Podcasts
+	Categories []Category
+	Explicit   *bool // nil when absent or not a known value
+	Image      string
+	Owner      Owner
+}
+
+// Item is an iTunes structure like describe in
+// https://help.apple.com/itc/podcasts_connect/#/itcb54353390
+// It holds the iTunes elements of an episode.
+type Item struct {
+	Author         string
+	Block          bool
+	Duration       time.Duration // 0 when absent or not valid
+	Episode        int
+	EpisodeType    string // full, trailer or bonus
+	Explicit       *bool
+	Image          string
+	InvalidEpisode string // the episode if it is not an integer
+	InvalidSeason  string // the season if it is not an integer
+	Season         int
+}
+
+// Category is an iTunes category, with its sub-categories
+type Category struct {
+	Categories []Category
+	Text       string
+}
+
+// Owner is the contact of the podcast, it is not shown to the listeners
+type Owner struct {
+	Email string
+	Name  string
+}
+
+// ParseDuration parses an itunes:duration, written as a number of seconds or
+// as HH:MM:SS or MM:SS. The seconds may have a fractional part.
+func ParseDuration(value string) (time.Duration, error) {
+	parts := strings.Split(strings.TrimSpace(value), ":")
+	if len(parts) > 3 {
+		return 0, errors.New("itunes: invalid duration '" + value + "'")
+	}
+
+	seconds, err := strconv.ParseFloat(parts[len(parts)-1], 64)
+	if err != nil || seconds < 0 || strings.ContainsAny(parts[len(parts)-1],
+		"eEnN+-") {
+		return 0, errors.New("itunes: invalid duration '" + value + "'")
+	}
+
+	if len(parts) > 1 && seconds >= 60 {
+		return 0, errors.New("itunes: invalid duration '" + value + "'")
+	}
+
+	res := time.Duration(seconds * float64(time.Second))
+	unit := time.Minute
+	for index := len(parts) - 2; index >= 0; index-- {
+		n, err := strconv.Atoi(parts[index])
+		if err != nil || n < 0 || strings.HasPrefix(parts[index], "+") ||
+			(index > 0 && n >= 60) {
+			return 0, errors.New("itunes: invalid duration '" + value + "'")
+		}
+
+		res += time.Duration(n) * unit
+		unit *= 60
+	}
+
+	return res, nil
+}
+
+// ParseExplicit parses an itunes:explicit. true and false are the values of
+// the specification, yes, explicit, no and clean are found in older podcasts.
+func ParseExplicit(value string) (bool, error) {
+	switch strings.ToLower(strings.TrimSpace(value)) {
+	case "true", "yes", "explicit":
+		return true, nil
+	case "false", "no", "clean":
+		return false, nil
+	}
+
+	return false, errors.New("itunes: invalid explicit '" + value + "'")
+}
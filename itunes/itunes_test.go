@@ -0,0 +1,146 @@
+// This file is part of clutch.
+//
+// clutch is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// clutch is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with Foobar.  If not, see <http://www.gnu.org/licenses/>.
+
+package itunes
+
+import (
+	"encoding/xml"
+	"io/ioutil"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/racam/clutch/internal/xmltest"
+)
+
+var prefix = "../testdata/itunes/"
+
+func TestElement(t *testing.T) {
+	channel := Channel{}
+	item := Item{}
+
+	var files = []struct {
+		filename string
+		element  func(name xml.Name) interface{}
+		skipped  int
+	}{
+		{"unit_01_channel.xml", channel.Element, 2},
+		{"unit_02_item.xml", item.Element, 1},
+	}
+
+	for _, file := range files {
+		data, err := ioutil.ReadFile(prefix + file.filename)
+		if err != nil {
+			t.Fatalf("[ITunes][Unit][Element] file '%s' : is missing",
+				prefix+file.filename)
+		}
+
+		skipped, err := xmltest.Decode(data, file.element)
+		if err != nil {
+			t.Fatalf("[ITunes][Unit][Element] file '%s' : %s", file.filename,
+				err)
+		}
+
+		if skipped != file.skipped {
+			t.Errorf("[ITunes][Unit][Element] file '%s' : expected %d "+
+				"skipped elements, actual %d", file.filename, file.skipped,
+				skipped)
+		}
+	}
+
+	explicit := false
+	expectedChannel := Channel{
+		Author: "John Doe",
+		Block:  true,
+		Categories: []Category{
+			{Text: "Technology"},
+			{Categories: []Category{{Text: "Documentary"}},
+				Text: "Society & Culture"},
+		},
+		Explicit: &explicit,
+		Image:    "http://example.org/podcast.jpg",
+		Owner:    Owner{Email: "john@example.org", Name: "John Doe"},
+	}
+
+	if !reflect.DeepEqual(channel, expectedChannel) {
+		t.Errorf("[ITunes][Unit][Element] expected %+v, actual %+v",
+			expectedChannel, channel)
+	}
+
+	expectedItem := Item{
+		Duration:      time.Hour + 2*time.Minute + 3*time.Second,
+		Episode:       12,
+		EpisodeType:   "full",
+		InvalidSeason: "invalid",
+	}
+
+	if !reflect.DeepEqual(item, expectedItem) {
+		t.Errorf("[ITunes][Unit][Element] expected %+v, actual %+v",
+			expectedItem, item)
+	}
+}
+
+func TestParseDuration(t *testing.T) {
+	var durations = []struct {
+		value    string
+		expected time.Duration
+		valid    bool
+	}{
+		{"3723", 3723 * time.Second, true},
+		{" 90.5 ", 90*time.Second + 500*time.Millisecond, true},
+		{"62:03", 62*time.Minute + 3*time.Second, true},
+		{"01:02:03", time.Hour + 2*time.Minute + 3*time.Second, true},
+		{"100:00:00", 100 * time.Hour, true},
+		{"", 0, false},
+		{"1:60", 0, false},
+		{"1:60:00", 0, false},
+		{"1:2:3:4", 0, false},
+		{"-10", 0, false},
+		{"1e3", 0, false},
+		{"one hour", 0, false},
+	}
+
+	for _, d := range durations {
+		actual, err := ParseDuration(d.value)
+		if (err == nil) != d.valid || actual != d.expected {
+			t.Errorf("[ITunes][Unit][Duration] '%s' : expected %s (valid %t), "+
+				"actual %s (%v)", d.value, d.expected, d.valid, actual, err)
+		}
+	}
+}
+
+func TestParseExplicit(t *testing.T) {
+	var values = []struct {
+		value    string
+		expected bool
+		valid    bool
+	}{
+		{"true", true, true},
+		{"Yes", true, true},
+		{"explicit", true, true},
+		{" false ", false, true},
+		{"no", false, true},
+		{"clean", false, true},
+		{"maybe", false, false},
+	}
+
+	for _, v := range values {
+		actual, err := ParseExplicit(v.value)
+		if (err == nil) != v.valid || actual != v.expected {
+			t.Errorf("[ITunes][Unit][Explicit] '%s' : expected %t (valid %t), "+
+				"actual %t (%v)", v.value, v.expected, v.valid, actual, err)
+		}
+	}
+}
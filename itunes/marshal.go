@@ -0,0 +1,114 @@
+// This file is part of clutch.
+//
+// clutch is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// clutch is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with Foobar.  If not, see <http://www.gnu.org/licenses/>.
+
+package itunes
+
+import (
+	"encoding/xml"
+	"strconv"
+	"time"
+
+	"github.com/racam/clutch/internal/xmlenc"
+)
+
+// Write writes the iTunes elements of the channel, the empty ones are omitted
+func (c *Channel) Write(enc *xmlenc.Encoder) {
+	writeText(enc, "author", c.Author)
+	writeImage(enc, c.Image)
+	writeCategories(enc, c.Categories)
+	writeExplicit(enc, c.Explicit)
+
+	if c.Owner != (Owner{}) {
+		n := name("owner")
+		enc.Start(n)
+		writeText(enc, "name", c.Owner.Name)
+		writeText(enc, "email", c.Owner.Email)
+		enc.End(n)
+	}
+
+	writeBlock(enc, c.Block)
+}
+
+// Write writes the iTunes elements of the item, the empty ones are omitted.
+// The duration is written as a number of seconds.
+func (i *Item) Write(enc *xmlenc.Encoder) {
+	writeText(enc, "author", i.Author)
+	writeImage(enc, i.Image)
+
+	if i.Duration > 0 {
+		seconds := int64(i.Duration / time.Second)
+		writeText(enc, "duration", strconv.FormatInt(seconds, 10))
+	}
+
+	writeExplicit(enc, i.Explicit)
+	writeText(enc, "episodeType", i.EpisodeType)
+
+	if i.Season > 0 {
+		writeText(enc, "season", strconv.Itoa(i.Season))
+	}
+	if i.Episode > 0 {
+		writeText(enc, "episode", strconv.Itoa(i.Episode))
+	}
+
+	writeBlock(enc, i.Block)
+}
+
+func writeCategories(enc *xmlenc.Encoder, categories []Category) {
+	for _, c := range categories {
+		n := name("category")
+		enc.Start(n, xmlenc.Attr("text", c.Text))
+		writeCategories(enc, c.Categories)
+		enc.End(n)
+	}
+}
+
+func writeImage(enc *xmlenc.Encoder, href string) {
+	if href == "" {
+		return
+	}
+
+	n := name("image")
+	enc.Start(n, xmlenc.Attr("href", href))
+	enc.End(n)
+}
+
+func writeExplicit(enc *xmlenc.Encoder, explicit *bool) {
+	if explicit != nil {
+		writeText(enc, "explicit", strconv.FormatBool(*explicit))
+	}
+}
+
+func writeBlock(enc *xmlenc.Encoder, block bool) {
+	if block {
+		writeText(enc, "block", "Yes")
+	}
+}
+
+// writeText writes an element which only contains character data, it is
+// omitted if the value is empty
+func writeText(enc *xmlenc.Encoder, local string, value string) {
+	if value == "" {
+		return
+	}
+
+	n := name(local)
+	enc.Start(n)
+	enc.Text(value)
+	enc.End(n)
+}
+
+func name(local string) xml.Name {
+	return xml.Name{Space: Namespace, Local: local}
+}
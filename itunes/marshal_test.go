@@ -0,0 +1,66 @@
+// This file is part of clutch.
+//
+// clutch is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// clutch is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with Foobar.  If not, see <http://www.gnu.org/licenses/>.
+
+package itunes
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/racam/clutch/internal/xmlenc"
+)
+
+func TestWrite(t *testing.T) {
+	explicit := true
+	c := Channel{
+		Categories: []Category{{Categories: []Category{{Text: "Documentary"}},
+			Text: "Society & Culture"}},
+		Explicit: &explicit,
+		Owner:    Owner{Email: "john@example.org"},
+	}
+	i := Item{Duration: 90*time.Minute + 500*time.Millisecond, Episode: 3}
+
+	var buf bytes.Buffer
+	enc := xmlenc.NewEncoder(&buf)
+	c.Write(enc)
+	i.Write(enc)
+	if err := enc.Close(); err != nil {
+		t.Fatalf("[ITunes][Marshal] %s", err)
+	}
+
+	var expected = []string{
+		`<category xmlns="http://www.itunes.com/dtds/podcast-1.0.dtd" text="Society &amp; Culture">`,
+		`text="Documentary"`,
+		`<explicit xmlns="http://www.itunes.com/dtds/podcast-1.0.dtd">true</explicit>`,
+		`<email xmlns="http://www.itunes.com/dtds/podcast-1.0.dtd">john@example.org</email>`,
+		`<duration xmlns="http://www.itunes.com/dtds/podcast-1.0.dtd">5400</duration>`,
+		`<episode xmlns="http://www.itunes.com/dtds/podcast-1.0.dtd">3</episode>`,
+	}
+
+	for _, e := range expected {
+		if !strings.Contains(buf.String(), e) {
+			t.Errorf("[ITunes][Marshal] expected '%s' in\n%s", e, buf.String())
+		}
+	}
+
+	for _, e := range []string{"<author", "<name", "<season", "<block"} {
+		if strings.Contains(buf.String(), e) {
+			t.Errorf("[ITunes][Marshal] unexpected '%s' in\n%s", e,
+				buf.String())
+		}
+	}
+}
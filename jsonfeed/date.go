@@ -0,0 +1,34 @@
+// This file is part of clutch.
+//
+// clutch is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// clutch is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with Foobar.  If not, see <http://www.gnu.org/licenses/>.
+
+package jsonfeed
+
+import (
+	"time"
+
+	"github.com/racam/clutch/date"
+)
+
+// DatePublishedTime returns the publication date of the item, the date is
+// read by date.Parse which accepts the broken dates found in the wild
+func (i *Item) DatePublishedTime() (time.Time, error) {
+	return date.Parse(i.DatePublished)
+}
+
+// DateModifiedTime returns the modification date of the item, see
+// DatePublishedTime
+func (i *Item) DateModifiedTime() (time.Time, error) {
+	return date.Parse(i.DateModified)
+}
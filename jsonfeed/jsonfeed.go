@@ -0,0 +1,94 @@
+// This file is part of clutch.
+//
+// clutch is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// clutch is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with Foobar.  If not, see <http://www.gnu.org/licenses/>.
+
+// Package jsonfeed please Refer to https://jsonfeed.org/version/1.1
+package jsonfeed
+
+import "encoding/json"
+
+// Feed is a JSON Feed structure like describe in
+// https://jsonfeed.org/version/1.1#top-level-a-name-top-level-a
+type Feed struct {
+	Author      *Author    `json:"author,omitempty"` // deprecated in 1.1
+	Authors     []Author   `json:"authors,omitempty"`
+	Description string     `json:"description,omitempty"`
+	Expired     bool       `json:"expired,omitempty"`
+	Extensions  Extensions `json:"-"`
+	Favicon     string     `json:"favicon,omitempty"`
+	FeedURL     string     `json:"feed_url,omitempty"`
+	HomePageURL string     `json:"home_page_url,omitempty"`
+	Hubs        []Hub      `json:"hubs,omitempty"`
+	Icon        string     `json:"icon,omitempty"`
+	Items       []Item     `json:"items"`
+	Language    string     `json:"language,omitempty"`
+	NextURL     string     `json:"next_url,omitempty"`
+	Title       string     `json:"title"`
+	UserComment string     `json:"user_comment,omitempty"`
+	Version     string     `json:"version"`
+}
+
+// Item is a JSON Feed structure like describe in
+// https://jsonfeed.org/version/1.1#items-a-name-items-a
+type Item struct {
+	Attachments   []Attachment `json:"attachments,omitempty"`
+	Author        *Author      `json:"author,omitempty"` // deprecated in 1.1
+	Authors       []Author     `json:"authors,omitempty"`
+	BannerImage   string       `json:"banner_image,omitempty"`
+	ContentHTML   string       `json:"content_html,omitempty"`
+	ContentText   string       `json:"content_text,omitempty"`
+	DateModified  string       `json:"date_modified,omitempty"`
+	DatePublished string       `json:"date_published,omitempty"`
+	Extensions    Extensions   `json:"-"`
+	ExternalURL   string       `json:"external_url,omitempty"`
+	ID            string       `json:"id"`
+	Image         string       `json:"image,omitempty"`
+	Language      string       `json:"language,omitempty"`
+	Summary       string       `json:"summary,omitempty"`
+	Tags          []string     `json:"tags,omitempty"`
+	Title         string       `json:"title,omitempty"`
+	URL           string       `json:"url,omitempty"`
+}
+
+// Author is a JSON Feed structure like describe in
+// https://jsonfeed.org/version/1.1#authors-a-name-authors-a
+type Author struct {
+	Avatar     string     `json:"avatar,omitempty"`
+	Extensions Extensions `json:"-"`
+	Name       string     `json:"name,omitempty"`
+	URL        string     `json:"url,omitempty"`
+}
+
+// Attachment is a JSON Feed structure like describe in
+// https://jsonfeed.org/version/1.1#attachments-a-name-attachments-a
+type Attachment struct {
+	DurationInSeconds float64 `json:"duration_in_seconds,omitempty"`
+	MIMEType          string  `json:"mime_type"`
+	SizeInBytes       int64   `json:"size_in_bytes,omitempty"`
+	Title             string  `json:"title,omitempty"`
+	URL               string  `json:"url"`
+}
+
+// Hub is a JSON Feed structure like describe in
+// https://jsonfeed.org/version/1.1#subscribing-to-real-time-notifications-a-name-subscribing-to-real-time-notifications-a
+type Hub struct {
+	Type string `json:"type"`
+	URL  string `json:"url"`
+}
+
+// Extensions holds the custom objects of a publisher, i.e. every key that
+// begins with an underscore, like describe in
+// https://jsonfeed.org/version/1.1#extensions-a-name-extensions-a
+// The keys keep their leading underscore.
+type Extensions map[string]json.RawMessage
@@ -0,0 +1,187 @@
+// This file is part of clutch.
+//
+// clutch is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// clutch is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with Foobar.  If not, see <http://www.gnu.org/licenses/>.
+
+package jsonfeed
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"io"
+	"strings"
+)
+
+// Versions of JSON Feed, as written in the version field of the document
+const (
+	Version10 string = "https://jsonfeed.org/version/1"
+	Version11 string = "https://jsonfeed.org/version/1.1"
+)
+
+// versionPrefix is shared by all the versions, the future ones included
+const versionPrefix string = "https://jsonfeed.org/version/"
+
+// jsonDeclaration is a simple struct to test if the json seems to be a JSON
+// Feed document
+type jsonDeclaration struct {
+	Version string `json:"version"`
+}
+
+// IsDeclared tries to find a version field at the root of the json document
+// with a JSON Feed URL
+// source : https://jsonfeed.org/version/1.1#top-level-a-name-top-level-a
+func IsDeclared(data []byte) bool {
+	j := jsonDeclaration{}
+	err := json.Unmarshal(data, &j)
+
+	if err != nil {
+		return false
+	}
+
+	return strings.HasPrefix(j.Version, versionPrefix)
+}
+
+// Parse parses the JSON-encoded data into a Feed struct and return it.
+// The deprecated 1.0 author fields are copied into the 1.1 authors fields when
+// the latter are absent, so that readers only need to look at the authors.
+func Parse(data []byte) (*Feed, error) {
+	return ParseReader(bytes.NewReader(data))
+}
+
+// ParseReader parses the JSON Feed document read from r, see Parse. The
+// document is decoded in a single pass, however the json package reads the
+// whole value before decoding it.
+func ParseReader(r io.Reader) (*Feed, error) {
+	f := Feed{}
+
+	err := json.NewDecoder(r).Decode(&f)
+	if err != nil {
+		return nil, err
+	}
+
+	if !strings.HasPrefix(f.Version, versionPrefix) {
+		return nil, errors.New("jsonfeed: version MUST be a JSON Feed URL")
+	}
+
+	if len(f.Authors) == 0 && f.Author != nil {
+		f.Authors = []Author{*f.Author}
+	}
+
+	for index := range f.Items {
+		i := &f.Items[index]
+		if len(i.Authors) == 0 && i.Author != nil {
+			i.Authors = []Author{*i.Author}
+		}
+	}
+
+	return &f, nil
+}
+
+// ShortVersion returns the version of the document without the JSON Feed
+// URL, i.e. "1.0" or "1.1"
+func (f *Feed) ShortVersion() string {
+	v, _ := ShortVersion(f.Version)
+	return v
+}
+
+// ShortVersion returns the version without the JSON Feed URL, i.e. "1.0" for
+// Version10 or "1.1" for Version11. It returns false if the version is not a
+// JSON Feed URL.
+func ShortVersion(version string) (string, bool) {
+	if !strings.HasPrefix(version, versionPrefix) {
+		return "", false
+	}
+
+	v := strings.TrimPrefix(version, versionPrefix)
+	if v == "1" {
+		return "1.0", true
+	}
+
+	return v, true
+}
+
+// UnmarshalJSON fills the feed and its extensions
+func (f *Feed) UnmarshalJSON(data []byte) error {
+	type feed Feed // avoid a recursive call of UnmarshalJSON
+	err := json.Unmarshal(data, (*feed)(f))
+	if err != nil {
+		return err
+	}
+
+	f.Extensions, err = parseExtensions(data)
+	return err
+}
+
+// UnmarshalJSON fills the item and its extensions. The id MUST be a string
+// but some publishers use a number, it is converted.
+func (i *Item) UnmarshalJSON(data []byte) error {
+	type item Item // avoid a recursive call of UnmarshalJSON
+	tmp := struct {
+		*item
+		ID json.RawMessage `json:"id"`
+	}{item: (*item)(i)}
+
+	err := json.Unmarshal(data, &tmp)
+	if err != nil {
+		return err
+	}
+
+	if len(tmp.ID) > 0 && tmp.ID[0] == '"' {
+		err = json.Unmarshal(tmp.ID, &i.ID)
+		if err != nil {
+			return err
+		}
+	} else if !bytes.Equal(tmp.ID, []byte("null")) {
+		i.ID = string(tmp.ID)
+	}
+
+	i.Extensions, err = parseExtensions(data)
+	return err
+}
+
+// UnmarshalJSON fills the author and its extensions
+func (a *Author) UnmarshalJSON(data []byte) error {
+	type author Author // avoid a recursive call of UnmarshalJSON
+	err := json.Unmarshal(data, (*author)(a))
+	if err != nil {
+		return err
+	}
+
+	a.Extensions, err = parseExtensions(data)
+	return err
+}
+
+// parseExtensions returns the keys of the object that begin with an
+// underscore, nil if there is none
+func parseExtensions(data []byte) (Extensions, error) {
+	var fields map[string]json.RawMessage
+	err := json.Unmarshal(data, &fields)
+	if err != nil {
+		return nil, err
+	}
+
+	var ext Extensions
+	for key, value := range fields {
+		if !strings.HasPrefix(key, "_") {
+			continue
+		}
+
+		if ext == nil {
+			ext = make(Extensions)
+		}
+		ext[key] = value
+	}
+
+	return ext, nil
+}
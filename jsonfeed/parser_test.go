@@ -0,0 +1,167 @@
+// This file is part of clutch.
+//
+// clutch is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// clutch is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with Foobar.  If not, see <http://www.gnu.org/licenses/>.
+
+package jsonfeed
+
+import (
+	"io/ioutil"
+	"testing"
+)
+
+var prefix = "../testdata/jsonfeed/"
+
+func TestIsDeclared(t *testing.T) {
+	var versions = []struct {
+		filename string // input file
+		expected bool   // expected result
+	}{
+		{"unit_01_IsDeclared.json", true},
+		{"unit_02_IsDeclared.json", true},
+		{"unit_03_IsDeclared.json", false},
+		{"unit_04_IsDeclared.json", false},
+	}
+
+	for _, v := range versions {
+
+		f, err := ioutil.ReadFile(prefix + v.filename)
+
+		if err != nil {
+			t.Errorf("[JSON][Unit][IsDeclared] file '%s' : is missing",
+				prefix+v.filename)
+		} else {
+			res := IsDeclared(f)
+
+			//Test if isDeclared return an expected result
+			if res != v.expected {
+				t.Errorf(`[JSON][Unit] file '%s' : expected result '%t',
+					actual %t`, v.filename, v.expected, res)
+			}
+		}
+	}
+}
+
+func TestParse(t *testing.T) {
+	filename := "unit_05_parse.json"
+	data, err := ioutil.ReadFile(prefix + filename)
+
+	if err != nil {
+		t.Fatalf("[JSON][Unit][Parse] file '%s' : is missing", prefix+filename)
+	}
+
+	f, err := Parse(data)
+	if err != nil {
+		t.Fatalf("[JSON][Unit][Parse] file '%s' : %s", filename, err)
+	}
+
+	if f.ShortVersion() != "1.1" {
+		t.Errorf("[JSON][Unit][Parse] Version : expected '1.1', actual '%s'",
+			f.ShortVersion())
+	}
+
+	if len(f.Authors) != 1 || f.Authors[0].Name != "Jane Doe" {
+		t.Errorf("[JSON][Unit][Parse] Authors : unexpected %+v", f.Authors)
+	} else if string(f.Authors[0].Extensions["_team"]) != `"blue"` {
+		t.Errorf("[JSON][Unit][Parse] Authors._team : unexpected %s",
+			f.Authors[0].Extensions["_team"])
+	}
+
+	if len(f.Hubs) != 1 || f.Hubs[0].Type != "WebSub" {
+		t.Errorf("[JSON][Unit][Parse] Hubs : unexpected %+v", f.Hubs)
+	}
+
+	if _, ok := f.Extensions["_pricing"]; !ok || len(f.Extensions) != 1 {
+		t.Errorf("[JSON][Unit][Parse] Extensions : unexpected %+v",
+			f.Extensions)
+	}
+
+	if len(f.Items) != 1 {
+		t.Fatalf("[JSON][Unit][Parse] Items : expected 1 item, actual %d",
+			len(f.Items))
+	}
+
+	i := f.Items[0]
+	if i.ID != "1" || i.ContentHTML != "<p>Hello</p>" || len(i.Tags) != 2 {
+		t.Errorf("[JSON][Unit][Parse] Item : unexpected %+v", i)
+	}
+
+	if len(i.Attachments) != 1 || i.Attachments[0].SizeInBytes != 1234 ||
+		i.Attachments[0].DurationInSeconds != 60.5 {
+		t.Errorf("[JSON][Unit][Parse] Attachments : unexpected %+v",
+			i.Attachments)
+	}
+
+	if string(i.Extensions["_pricing"]) != `{"amount": 10}` {
+		t.Errorf("[JSON][Unit][Parse] Item.Extensions : unexpected %s",
+			i.Extensions["_pricing"])
+	}
+}
+
+func TestParseVersion10(t *testing.T) {
+	filename := "unit_06_parse_10.json"
+	data, err := ioutil.ReadFile(prefix + filename)
+
+	if err != nil {
+		t.Fatalf("[JSON][Unit][Parse] file '%s' : is missing", prefix+filename)
+	}
+
+	f, err := Parse(data)
+	if err != nil {
+		t.Fatalf("[JSON][Unit][Parse] file '%s' : %s", filename, err)
+	}
+
+	if f.ShortVersion() != "1.0" {
+		t.Errorf("[JSON][Unit][Parse] Version : expected '1.0', actual '%s'",
+			f.ShortVersion())
+	}
+
+	// The deprecated author is copied into authors
+	if len(f.Authors) != 1 || f.Authors[0].Name != "John Doe" {
+		t.Errorf("[JSON][Unit][Parse] Authors : unexpected %+v", f.Authors)
+	}
+
+	if len(f.Items) != 1 {
+		t.Fatalf("[JSON][Unit][Parse] Items : expected 1 item, actual %d",
+			len(f.Items))
+	}
+
+	// A numeric id is converted to a string
+	if f.Items[0].ID != "42" {
+		t.Errorf("[JSON][Unit][Parse] Item.ID : expected '42', actual '%s'",
+			f.Items[0].ID)
+	}
+
+	if len(f.Items[0].Authors) != 1 || f.Items[0].Authors[0].Name != "Jane Doe" {
+		t.Errorf("[JSON][Unit][Parse] Item.Authors : unexpected %+v",
+			f.Items[0].Authors)
+	}
+}
+
+func TestFailParse(t *testing.T) {
+	filename := "unit_04_IsDeclared.json"
+	data, err := ioutil.ReadFile(prefix + filename)
+
+	if err != nil {
+		t.Fatalf("[JSON][Unit][Parse] file '%s' : is missing", prefix+filename)
+	}
+
+	res, err := Parse(data)
+	if res != nil {
+		t.Errorf("[JSON][Unit][Parse] file '%s' : Feed is not nil", filename)
+	}
+
+	if err == nil {
+		t.Errorf("[JSON][Unit][Parse] file '%s' : error is nil", filename)
+	}
+}
@@ -0,0 +1,246 @@
+// This file is part of clutch.
+//
+// clutch is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// clutch is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with Foobar.  If not, see <http://www.gnu.org/licenses/>.
+
+package media
+
+import (
+	"encoding/xml"
+	"strconv"
+	"strings"
+
+	"github.com/racam/clutch/internal/xmldec"
+)
+
+// inMedia returns true for the namespace of the Media RSS elements
+var inMedia = xmldec.InNamespace(Namespace)
+
+// Element returns the value into which the element, a child of an item, must
+// be decoded, nil if it is not a Media RSS element. The contents, the groups
+// and the repeatable optional elements are appended.
+func (m *Media) Element(name xml.Name) interface{} {
+	if name.Space != Namespace {
+		return nil
+	}
+
+	switch name.Local {
+	case "content":
+		m.Contents = append(m.Contents, Content{})
+		return &m.Contents[len(m.Contents)-1]
+	case "group":
+		m.Groups = append(m.Groups, Group{})
+		return &m.Groups[len(m.Groups)-1]
+	}
+
+	return m.Elements.element(name.Local)
+}
+
+// element returns the value of an optional element, nil if the name is not
+// one of them
+func (e *Elements) element(local string) interface{} {
+	switch local {
+	case "category":
+		e.Categories = append(e.Categories, Category{})
+		return &e.Categories[len(e.Categories)-1]
+	case "community":
+		return &e.Community
+	case "copyright":
+		return &e.Copyright
+	case "credit":
+		e.Credits = append(e.Credits, Credit{})
+		return &e.Credits[len(e.Credits)-1]
+	case "description":
+		return &e.Description
+	case "keywords":
+		return (*keywords)(&e.Keywords)
+	case "player":
+		return &e.Player
+	case "rating":
+		e.Ratings = append(e.Ratings, Rating{})
+		return &e.Ratings[len(e.Ratings)-1]
+	case "thumbnail":
+		e.Thumbnails = append(e.Thumbnails, Thumbnail{})
+		return &e.Thumbnails[len(e.Thumbnails)-1]
+	case "title":
+		return &e.Title
+	}
+
+	return nil
+}
+
+// UnmarshalXML decodes the media:group element, see xmldec.DecodeChildren
+func (g *Group) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
+	return xmldec.DecodeChildren(d, inMedia, func(local string) interface{} {
+		if local == "content" {
+			g.Contents = append(g.Contents, Content{})
+			return &g.Contents[len(g.Contents)-1]
+		}
+		return g.Elements.element(local)
+	}, nil)
+}
+
+// UnmarshalXML decodes the media:content element. The feeds are read
+// leniently : the attributes are matched whatever their case and a number
+// which is not valid is decoded as 0.
+func (c *Content) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
+	for _, a := range start.Attr {
+		switch strings.ToLower(a.Name.Local) {
+		case "bitrate":
+			c.Bitrate = atof(a.Value)
+		case "channels":
+			c.Channels = atoi(a.Value)
+		case "duration":
+			c.Duration = atoi(a.Value)
+		case "expression":
+			c.Expression = a.Value
+		case "filesize":
+			c.FileSize, _ = strconv.ParseInt(strings.TrimSpace(a.Value), 10, 64)
+		case "framerate":
+			c.Framerate = atof(a.Value)
+		case "height":
+			c.Height = atoi(a.Value)
+		case "isdefault":
+			c.IsDefault = strings.TrimSpace(a.Value) == "true"
+		case "lang":
+			c.Lang = a.Value
+		case "medium":
+			c.Medium = a.Value
+		case "samplingrate":
+			c.SamplingRate = atof(a.Value)
+		case "type":
+			c.Type = a.Value
+		case "url":
+			c.URL = a.Value
+		case "width":
+			c.Width = atoi(a.Value)
+		}
+	}
+
+	return xmldec.DecodeChildren(d, inMedia, c.Elements.element, nil)
+}
+
+// UnmarshalXML decodes the media:community element, see xmldec.DecodeChildren
+func (c *Community) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
+	return xmldec.DecodeChildren(d, inMedia, func(local string) interface{} {
+		switch local {
+		case "starRating":
+			return &c.StarRating
+		case "statistics":
+			return &c.Statistics
+		case "tags":
+			return &c.Tags
+		}
+		return nil
+	}, nil)
+}
+
+// UnmarshalXML decodes the media:starRating element, a number which is not
+// valid is decoded as 0
+func (s *StarRating) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
+	for _, a := range start.Attr {
+		switch a.Name.Local {
+		case "average":
+			s.Average = atof(a.Value)
+		case "count":
+			s.Count = atoi(a.Value)
+		case "max":
+			s.Max = atoi(a.Value)
+		case "min":
+			s.Min = atoi(a.Value)
+		}
+	}
+
+	return d.Skip()
+}
+
+// UnmarshalXML decodes the media:statistics element, a number which is not
+// valid is decoded as 0
+func (s *Statistics) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
+	for _, a := range start.Attr {
+		switch a.Name.Local {
+		case "favorites":
+			s.Favorites, _ = strconv.ParseInt(strings.TrimSpace(a.Value), 10, 64)
+		case "views":
+			s.Views, _ = strconv.ParseInt(strings.TrimSpace(a.Value), 10, 64)
+		}
+	}
+
+	return d.Skip()
+}
+
+// UnmarshalXML decodes the media:player element, a size which is not valid is
+// decoded as 0
+func (p *Player) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
+	for _, a := range start.Attr {
+		switch a.Name.Local {
+		case "height":
+			p.Height = atoi(a.Value)
+		case "url":
+			p.URL = a.Value
+		case "width":
+			p.Width = atoi(a.Value)
+		}
+	}
+
+	return d.Skip()
+}
+
+// UnmarshalXML decodes the media:thumbnail element, a size which is not valid
+// is decoded as 0
+func (t *Thumbnail) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
+	for _, a := range start.Attr {
+		switch a.Name.Local {
+		case "height":
+			t.Height = atoi(a.Value)
+		case "time":
+			t.Time = a.Value
+		case "url":
+			t.URL = a.Value
+		case "width":
+			t.Width = atoi(a.Value)
+		}
+	}
+
+	return d.Skip()
+}
+
+// keywords are the comma separated keywords of media:keywords
+type keywords []string
+
+// UnmarshalXML decodes the media:keywords element, the empty keywords are
+// dropped
+func (k *keywords) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
+	var value string
+	if err := d.DecodeElement(&value, &start); err != nil {
+		return err
+	}
+
+	for _, v := range strings.Split(value, ",") {
+		if v = strings.TrimSpace(v); v != "" {
+			*k = append(*k, v)
+		}
+	}
+
+	return nil
+}
+
+func atoi(s string) int {
+	i, _ := strconv.Atoi(strings.TrimSpace(s))
+	return i
+}
+
+func atof(s string) float64 {
+	f, _ := strconv.ParseFloat(strings.TrimSpace(s), 64)
+	return f
+}
@@ -0,0 +1,174 @@
+// This file is part of clutch.
+//
+// clutch is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// clutch is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with Foobar.  If not, see <http://www.gnu.org/licenses/>.
+
+package media
+
+import (
+	"encoding/xml"
+	"strconv"
+	"strings"
+
+	"github.com/racam/clutch/internal/xmlenc"
+)
+
+// Write writes the Media RSS elements of an item : its optional elements, its
+// contents then its groups. The empty elements and attributes, and the zero
+// numbers, are omitted.
+func (m *Media) Write(enc *xmlenc.Encoder) {
+	m.Elements.write(enc)
+	writeContents(enc, m.Contents)
+
+	for _, g := range m.Groups {
+		n := name("group")
+		enc.Start(n)
+		g.Elements.write(enc)
+		writeContents(enc, g.Contents)
+		enc.End(n)
+	}
+}
+
+func writeContents(enc *xmlenc.Encoder, contents []Content) {
+	for _, c := range contents {
+		isDefault := ""
+		if c.IsDefault {
+			isDefault = "true"
+		}
+
+		n := name("content")
+		enc.Start(n,
+			xmlenc.Attr("url", c.URL),
+			xmlenc.Attr("fileSize", itoa(c.FileSize)),
+			xmlenc.Attr("type", c.Type),
+			xmlenc.Attr("medium", c.Medium),
+			xmlenc.Attr("isDefault", isDefault),
+			xmlenc.Attr("expression", c.Expression),
+			xmlenc.Attr("bitrate", ftoa(c.Bitrate)),
+			xmlenc.Attr("framerate", ftoa(c.Framerate)),
+			xmlenc.Attr("samplingrate", ftoa(c.SamplingRate)),
+			xmlenc.Attr("channels", itoa(int64(c.Channels))),
+			xmlenc.Attr("duration", itoa(int64(c.Duration))),
+			xmlenc.Attr("height", itoa(int64(c.Height))),
+			xmlenc.Attr("width", itoa(int64(c.Width))),
+			xmlenc.Attr("lang", c.Lang))
+		c.Elements.write(enc)
+		enc.End(n)
+	}
+}
+
+// write writes the optional elements
+func (e *Elements) write(enc *xmlenc.Encoder) {
+	writeText(enc, "title", e.Title)
+	writeText(enc, "description", e.Description)
+	writeText(enc, "keywords", Text{Value: strings.Join(e.Keywords, ", ")})
+
+	for _, t := range e.Thumbnails {
+		writeEmpty(enc, "thumbnail",
+			xmlenc.Attr("url", t.URL),
+			xmlenc.Attr("height", itoa(int64(t.Height))),
+			xmlenc.Attr("width", itoa(int64(t.Width))),
+			xmlenc.Attr("time", t.Time))
+	}
+
+	for _, c := range e.Categories {
+		writeText(enc, "category", Text{Value: c.Value},
+			xmlenc.Attr("scheme", c.Scheme), xmlenc.Attr("label", c.Label))
+	}
+
+	if e.Player.URL != "" {
+		writeEmpty(enc, "player",
+			xmlenc.Attr("url", e.Player.URL),
+			xmlenc.Attr("height", itoa(int64(e.Player.Height))),
+			xmlenc.Attr("width", itoa(int64(e.Player.Width))))
+	}
+
+	for _, c := range e.Credits {
+		writeText(enc, "credit", Text{Value: c.Value},
+			xmlenc.Attr("role", c.Role), xmlenc.Attr("scheme", c.Scheme))
+	}
+
+	writeText(enc, "copyright", Text{Value: e.Copyright.Value},
+		xmlenc.Attr("url", e.Copyright.URL))
+
+	for _, r := range e.Ratings {
+		writeText(enc, "rating", Text{Value: r.Value},
+			xmlenc.Attr("scheme", r.Scheme))
+	}
+
+	e.Community.write(enc)
+}
+
+// write writes the community, it is omitted if it is empty
+func (c *Community) write(enc *xmlenc.Encoder) {
+	if *c == (Community{}) {
+		return
+	}
+
+	n := name("community")
+	enc.Start(n)
+	if c.StarRating != (StarRating{}) {
+		writeEmpty(enc, "starRating",
+			xmlenc.Attr("average", ftoa(c.StarRating.Average)),
+			xmlenc.Attr("count", itoa(int64(c.StarRating.Count))),
+			xmlenc.Attr("min", itoa(int64(c.StarRating.Min))),
+			xmlenc.Attr("max", itoa(int64(c.StarRating.Max))))
+	}
+	if c.Statistics != (Statistics{}) {
+		writeEmpty(enc, "statistics",
+			xmlenc.Attr("views", itoa(c.Statistics.Views)),
+			xmlenc.Attr("favorites", itoa(c.Statistics.Favorites)))
+	}
+	writeText(enc, "tags", Text{Value: c.Tags})
+	enc.End(n)
+}
+
+// writeText writes an element which contains character data, it is omitted
+// if the value is empty
+func writeText(enc *xmlenc.Encoder, local string, t Text, attrs ...xml.Attr) {
+	if t.Value == "" {
+		return
+	}
+
+	n := name(local)
+	enc.Start(n, append([]xml.Attr{xmlenc.Attr("type", t.Type)}, attrs...)...)
+	enc.Text(t.Value)
+	enc.End(n)
+}
+
+// writeEmpty writes an element which only has attributes
+func writeEmpty(enc *xmlenc.Encoder, local string, attrs ...xml.Attr) {
+	n := name(local)
+	enc.Start(n, attrs...)
+	enc.End(n)
+}
+
+func name(local string) xml.Name {
+	return xml.Name{Space: Namespace, Local: local}
+}
+
+// itoa returns the decimal representation of i, the empty string for 0
+func itoa(i int64) string {
+	if i == 0 {
+		return ""
+	}
+	return strconv.FormatInt(i, 10)
+}
+
+// ftoa returns the decimal representation of f, the empty string for 0
+func ftoa(f float64) string {
+	if f == 0 {
+		return ""
+	}
+	return strconv.FormatFloat(f, 'f', -1, 64)
+}
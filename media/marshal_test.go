@@ -0,0 +1,62 @@
+// This file is part of clutch.
+//
+// clutch is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// clutch is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with Foobar.  If not, see <http://www.gnu.org/licenses/>.
+
+package media
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/racam/clutch/internal/xmlenc"
+)
+
+func TestWrite(t *testing.T) {
+	m := Media{
+		Elements: Elements{
+			Keywords:   []string{"go", "feeds"},
+			Thumbnails: []Thumbnail{{URL: "http://example.com/item.jpg", Width: 120}},
+		},
+		Groups: []Group{{
+			Contents: []Content{{Bitrate: 128, Type: "audio/mpeg",
+				URL: "http://example.com/song.mp3"}},
+		}},
+	}
+
+	var buf bytes.Buffer
+	enc := xmlenc.NewEncoder(&buf)
+	m.Write(enc)
+	if err := enc.Close(); err != nil {
+		t.Fatalf("[Media][Marshal] %s", err)
+	}
+
+	var expected = []string{
+		`<keywords xmlns="http://search.yahoo.com/mrss/">go, feeds</keywords>`,
+		`<thumbnail xmlns="http://search.yahoo.com/mrss/" url="http://example.com/item.jpg" width="120"></thumbnail>`,
+		`<content xmlns="http://search.yahoo.com/mrss/" url="http://example.com/song.mp3" type="audio/mpeg" bitrate="128"></content>`,
+	}
+
+	for _, e := range expected {
+		if !strings.Contains(buf.String(), e) {
+			t.Errorf("[Media][Marshal] expected '%s' in\n%s", e, buf.String())
+		}
+	}
+
+	for _, e := range []string{"<title", "<community", "<player", "height="} {
+		if strings.Contains(buf.String(), e) {
+			t.Errorf("[Media][Marshal] unexpected '%s' in\n%s", e, buf.String())
+		}
+	}
+}
@@ -0,0 +1,159 @@
+// This file is part of clutch.
+//
+// clutch is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// clutch is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with Foobar.  If not, see <http://www.gnu.org/licenses/>.
+
+// Package media please Refer to https://www.rssboard.org/media-rss
+package media
+
+import "github.com/racam/clutch/ext"
+
+// Namespace is the namespace of Media RSS
+// source : https://www.rssboard.org/media-rss#namespace-declaration
+const Namespace string = "http://search.yahoo.com/mrss/"
+
+func init() {
+	ext.RegisterBuiltin(Namespace, func() ext.Module { return &Media{} })
+}
+
+// Media is a Media RSS structure like describe in
+// https://www.rssboard.org/media-rss#primary-elements
+// It holds the Media RSS elements of an item : its contents, alone or in
+// groups, and the optional elements which apply to all of them.
+type Media struct {
+	Elements
+	Contents []Content
+	Groups   []Group
+}
+
+// Group is a Media RSS structure like describe in
+// https://www.rssboard.org/media-rss#media-group
+// Its contents are different representations of the same object, its optional
+// elements apply to all of them.
+type Group struct {
+	Elements
+	Contents []Content
+}
+
+// Content is a Media RSS structure like describe in
+// https://www.rssboard.org/media-rss#media-content
+type Content struct {
+	Elements
+	Bitrate      float64 // kilobits per second
+	Channels     int
+	Duration     int // seconds
+	Expression   string
+	FileSize     int64 // bytes
+	Framerate    float64
+	Height       int
+	IsDefault    bool
+	Lang         string
+	Medium       string
+	SamplingRate float64 // kilosamples per second
+	Type         string
+	URL          string
+	Width        int
+}
+
+// Elements are the optional elements of Media RSS, they are children of an
+// item, a group or a content. The elements of a content override the ones of
+// its group, which override the ones of the item.
+// source : https://www.rssboard.org/media-rss#optional-elements
+type Elements struct {
+	Categories  []Category
+	Community   Community
+	Copyright   Copyright
+	Credits     []Credit
+	Description Text
+	Keywords    []string
+	Player      Player
+	Ratings     []Rating
+	Thumbnails  []Thumbnail
+	Title       Text
+}
+
+// Category is a Media RSS structure like describe in
+// https://www.rssboard.org/media-rss#media-category
+type Category struct {
+	Label  string `xml:"label,attr"`
+	Scheme string `xml:"scheme,attr"`
+	Value  string `xml:",chardata"`
+}
+
+// Community is a Media RSS structure like describe in
+// https://www.rssboard.org/media-rss#media-community
+type Community struct {
+	StarRating StarRating
+	Statistics Statistics
+	Tags       string // comma separated tags, with an optional weight
+}
+
+// StarRating is the average rating of a community
+type StarRating struct {
+	Average float64
+	Count   int
+	Max     int
+	Min     int
+}
+
+// Statistics are the views and favorites of a community
+type Statistics struct {
+	Favorites int64
+	Views     int64
+}
+
+// Copyright is a Media RSS structure like describe in
+// https://www.rssboard.org/media-rss#media-copyright
+type Copyright struct {
+	URL   string `xml:"url,attr"`
+	Value string `xml:",chardata"`
+}
+
+// Credit is a Media RSS structure like describe in
+// https://www.rssboard.org/media-rss#media-credit
+type Credit struct {
+	Role   string `xml:"role,attr"`
+	Scheme string `xml:"scheme,attr"`
+	Value  string `xml:",chardata"`
+}
+
+// Player is a Media RSS structure like describe in
+// https://www.rssboard.org/media-rss#media-player
+type Player struct {
+	Height int
+	URL    string
+	Width  int
+}
+
+// Rating is a Media RSS structure like describe in
+// https://www.rssboard.org/media-rss#media-rating
+type Rating struct {
+	Scheme string `xml:"scheme,attr"`
+	Value  string `xml:",chardata"`
+}
+
+// Text is a Media RSS title or description, whose type is plain or html
+// source : https://www.rssboard.org/media-rss#media-title
+type Text struct {
+	Type  string `xml:"type,attr"`
+	Value string `xml:",chardata"`
+}
+
+// Thumbnail is a Media RSS structure like describe in
+// https://www.rssboard.org/media-rss#media-thumbnails
+type Thumbnail struct {
+	Height int
+	Time   string // NTP time offset in the media
+	URL    string
+	Width  int
+}
@@ -0,0 +1,105 @@
+// This file is part of clutch.
+//
+// clutch is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// clutch is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with Foobar.  If not, see <http://www.gnu.org/licenses/>.
+
+package media
+
+import (
+	"encoding/xml"
+	"io/ioutil"
+	"reflect"
+	"testing"
+
+	"github.com/racam/clutch/internal/xmltest"
+)
+
+var prefix = "../testdata/media/"
+
+func TestElement(t *testing.T) {
+	filename := "unit_01_elements.xml"
+	data, err := ioutil.ReadFile(prefix + filename)
+	if err != nil {
+		t.Fatalf("[Media][Unit][Element] file '%s' : is missing",
+			prefix+filename)
+	}
+
+	actual := Media{}
+	skipped, err := xmltest.Decode(data, actual.Element)
+	if err != nil {
+		t.Fatalf("[Media][Unit][Element] file '%s' : %s", filename, err)
+	}
+
+	expected := Media{
+		Elements: Elements{
+			Community: Community{
+				StarRating: StarRating{Average: 3.5, Count: 20, Max: 10, Min: 1},
+				Statistics: Statistics{Views: 5},
+				Tags:       "news: 5, abc:3",
+			},
+			Keywords: []string{"go", "feeds", "media"},
+			Ratings:  []Rating{{Scheme: "urn:simple", Value: "adult"}},
+			Thumbnails: []Thumbnail{{Height: 90, Time: "12:05:01.123",
+				URL: "http://example.com/item.jpg", Width: 120}},
+			Title: Text{Type: "plain", Value: "The item"},
+		},
+		Contents: []Content{{
+			Elements: Elements{
+				Credits: []Credit{{Role: "producer", Scheme: "urn:ebu",
+					Value: "John Doe"}},
+				Thumbnails: []Thumbnail{{URL: "http://example.com/movie.jpg"}},
+			},
+			Duration:  185,
+			FileSize:  12216320,
+			IsDefault: true,
+			Medium:    "video",
+			Type:      "video/mp4",
+			URL:       "http://example.com/movie.mp4",
+		}},
+		Groups: []Group{{
+			Elements: Elements{
+				Categories: []Category{{Label: "Music",
+					Scheme: "http://example.com/scheme", Value: "music/rock"}},
+				Player: Player{Height: 200, URL: "http://example.com/player",
+					Width: 400},
+			},
+			Contents: []Content{
+				{Bitrate: 128, Channels: 2, SamplingRate: 44.1,
+					Type: "audio/mpeg", URL: "http://example.com/song.mp3"},
+				{Type: "audio/ogg", URL: "http://example.com/song.ogg"},
+			},
+		}},
+	}
+
+	if !reflect.DeepEqual(actual, expected) {
+		t.Errorf("[Media][Unit][Element] expected %+v, actual %+v", expected,
+			actual)
+	}
+
+	if skipped != 2 {
+		t.Errorf("[Media][Unit][Element] expected 2 skipped elements, actual %d",
+			skipped)
+	}
+}
+
+func TestElementName(t *testing.T) {
+	m := Media{}
+
+	if v := m.Element(xml.Name{Local: "content"}); v != nil {
+		t.Errorf("[Media][Unit][Element] content without namespace : expected nil")
+	}
+
+	if len(m.Contents) != 0 {
+		t.Errorf("[Media][Unit][Element] content without namespace is appended")
+	}
+}
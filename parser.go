@@ -16,60 +16,256 @@
 package clutch
 
 import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"encoding/xml"
 	"errors"
+	"io"
+	"net/url"
+	"strconv"
+	"strings"
 
 	"github.com/racam/clutch/atom"
+	"github.com/racam/clutch/internal/xmldec"
+	"github.com/racam/clutch/jsonfeed"
+	"github.com/racam/clutch/media"
+	"github.com/racam/clutch/rdf"
 	"github.com/racam/clutch/rss"
 )
 
-// Parse parses the ATOM-encoded data into an atom.Feed struct and return it.
-// The ATOM parsing do 2 steps :
-// * Check that the document is well declared as an ATOM document
-// * Parse the structure to fill additionnals fields
+// Parse parses the data into a Feed struct and return it, see ParseReader
 func Parse(data []byte) (*Feed, error) {
+	return ParseReader(bytes.NewReader(data))
+}
+
+// ParseReader parses the document read from r into a Feed struct and return
+// it. The document is decoded in a single pass, without being buffered : the
+// first significant character tells if it is a JSON document, otherwise the
+// root element of the XML document tells which format it is.
+// The errors are ErrEmptyDocument, *SyntaxError, *UnsupportedVersionError,
+// ErrUnknownFormat for errors.Is, or the error of the reader.
+// The relative references of the Atom documents are resolved against their
+// xml:base attributes, see ParseReaderWithURL.
+func ParseReader(r io.Reader) (*Feed, error) {
+	return parseReader(r, nil)
+}
+
+// ParseWithURL parses the data into a Feed struct and return it, see
+// ParseReaderWithURL
+func ParseWithURL(data []byte, location string) (*Feed, error) {
+	return ParseReaderWithURL(bytes.NewReader(data), location)
+}
+
+// ParseReaderWithURL parses the document read from r like ParseReader.
+// location is the URL the document was retrieved from, the outermost base URI
+// of the relative references of the Atom documents, see
+// atom.Feed.ResolveURLs. The error of url.Parse is returned when location is
+// not a valid URL.
+func ParseReaderWithURL(r io.Reader, location string) (*Feed, error) {
+	document, err := url.Parse(location)
+	if err != nil {
+		return nil, err
+	}
+
+	return parseReader(r, document)
+}
+
+func parseReader(r io.Reader, document *url.URL) (*Feed, error) {
+	br := bufio.NewReader(r)
+
+	c, err := firstByte(br)
+	if err != nil {
+		return nil, err
+	}
+
+	// A JSON document can not be an XML one, there is no need to try the
+	// XML parsers
+	if c == '{' {
+		return readJSON(br)
+	}
+
+	return readXML(br, document)
+}
+
+func readJSON(br *bufio.Reader) (*Feed, error) {
+	tr := &trackReader{r: br, trackLines: true}
+
+	// The json package does not skip the byte order mark
+	if b, _ := br.Peek(len(bom)); bytes.Equal(b, bom) {
+		br.Discard(len(bom))
+		tr.offset = int64(len(bom))
+	}
+	base := tr.offset
+
+	j, err := jsonfeed.ParseReader(tr)
+	if err != nil {
+		var se *json.SyntaxError
+
+		switch {
+		case tr.err != nil:
+			return nil, tr.err
+		case errors.As(err, &se):
+			// Offset counts the bytes read until the invalid one included,
+			// like the position of the xml package
+			offset := base + se.Offset
+			line, column := tr.position(offset)
+			return nil, &SyntaxError{Column: column, Err: err,
+				FeedType: FeedTypeJSON, Line: line, Offset: offset}
+		case err == io.ErrUnexpectedEOF:
+			line, column := tr.position(tr.offset)
+			return nil, &SyntaxError{Column: column, Err: err,
+				FeedType: FeedTypeJSON, Line: line, Offset: tr.offset}
+		}
+
+		return nil, &formatError{err: err}
+	}
+
+	res := Feed{JSON: j, FeedType: FeedTypeJSON}
+	res.parseJSON()
+	return &res, nil
+}
+
+func readXML(br *bufio.Reader, document *url.URL) (*Feed, error) {
+	tr := &trackReader{r: br}
+	d := xmldec.NewDecoder(tr)
 	res := Feed{}
 
-	if rss, err := rss.Parse(data); err == nil {
-		res.RSS = rss
-		res.FeedType = FeedTypeRSS
-		res.parseRSS()
-	} else if atom, err2 := atom.Parse(data); err2 == nil {
-		res.Atom = atom
-		res.FeedType = FeedTypeAtom
-		res.parseAtom()
-	} else {
-		return nil, errors.New("Data is not recognize as RSS 2.0 or Atom 1.0")
+	start, err := xmldec.RootElement(d)
+	if err != nil {
+		return nil, xmlError(d, tr, FeedTypeUnknown, err)
+	}
+
+	res.FeedType = xmlFeedType(start)
+	switch res.FeedType {
+	case FeedTypeRSS:
+		// A missing version is tolerated, an unknown one is not
+		version := rss.DeclaredVersion(start)
+		if _, ok := rss.VersionSpec(version); !ok && version != "" {
+			return nil, &UnsupportedVersionError{FeedType: FeedTypeRSS,
+				Version: version}
+		}
+
+		if res.RSS, err = rss.ParseElement(d, start); err == nil {
+			res.parseRSS()
+		}
+	case FeedTypeAtom:
+		if res.Atom, err = atom.ParseElement(d, start); err == nil {
+			res.Atom.ResolveURLs(document)
+			res.parseAtom()
+		}
+	case FeedTypeRDF:
+		if res.RDF, err = rdf.ParseElement(d, start); err == nil {
+			res.parseRDF()
+		}
+	default:
+		return nil, ErrUnknownFormat
+	}
+
+	if err != nil {
+		return nil, xmlError(d, tr, res.FeedType, err)
 	}
 
 	return &res, nil
 }
 
+// xmlError returns the error of the parsing of an XML document : the error of
+// the reader, a *SyntaxError at the position of the decoder or a formatError
+func xmlError(d *xml.Decoder, tr *trackReader, feedType FeedType,
+	err error) error {
+	if tr.err != nil {
+		return tr.err
+	}
+
+	var se *xml.SyntaxError
+	if errors.As(err, &se) {
+		line, column := d.InputPos()
+		return &SyntaxError{Column: column, Err: err, FeedType: feedType,
+			Line: line, Offset: d.InputOffset()}
+	}
+
+	return &formatError{err: err}
+}
+
 func (f *Feed) parseRSS() {
-	f.Author = &f.RSS.Channel.ManagingEditor
+	// The Dublin Core elements are used when the RSS ones are absent
+	dc := &f.RSS.Channel.DublinCore
+
+	f.Author = fallback(&f.RSS.Channel.ManagingEditor, dc.Creator)
 	f.Description = &f.RSS.Channel.Description
+	f.Extensions = f.RSS.Channel.Extensions
+	f.Modules = f.RSS.Channel.Modules
 	f.Generator = &f.RSS.Channel.Generator
-	f.Language = &f.RSS.Channel.Language
+	f.ITunes = &f.RSS.Channel.ITunes
+	f.Language = fallback(&f.RSS.Channel.Language, dc.Language)
 	f.Link = &f.RSS.Channel.Link
 	f.Logo = &f.RSS.Channel.Image.URL
-	f.Rights = &f.RSS.Channel.Copyright
+	f.Rights = fallback(&f.RSS.Channel.Copyright, dc.Rights)
 	f.Title = &f.RSS.Channel.Title
-	f.Updated = &f.RSS.Channel.PubDate
+	f.Updated = fallback(&f.RSS.Channel.PubDate, dc.Date, dc.Modified)
+	f.Version = f.RSS.Version
+
+	// The iTunes author and image are used when the RSS ones are absent
+	if *f.Author == "" {
+		f.Author = &f.ITunes.Author
+	}
+	if *f.Logo == "" {
+		f.Logo = &f.ITunes.Image
+	}
 
 	f.Category = make([]*string, len(f.RSS.Channel.Category))
 	for index := range f.Category {
 		f.Category[index] = &f.RSS.Channel.Category[index].Content
 	}
+	if len(f.Category) == 0 {
+		f.Category = subjects(dc.Subject)
+	}
 
 	f.Entry = make([]Entry, len(f.RSS.Channel.Item))
 	for index := range f.Entry {
-		f.Entry[index].Author = &f.RSS.Channel.Item[index].Author
+		dc := &f.RSS.Channel.Item[index].DublinCore
+
+		f.Entry[index].Author = fallback(&f.RSS.Channel.Item[index].Author,
+			dc.Creator)
+		if *f.Entry[index].Author == "" {
+			f.Entry[index].Author = &f.RSS.Channel.Item[index].ITunes.Author
+		}
+		f.Entry[index].ITunes = &f.RSS.Channel.Item[index].ITunes
 		f.Entry[index].Title = &f.RSS.Channel.Item[index].Title
 		f.Entry[index].Description = &f.RSS.Channel.Item[index].Description
+		f.Entry[index].Extensions = f.RSS.Channel.Item[index].Extensions
+		f.Entry[index].Modules = f.RSS.Channel.Item[index].Modules
 		f.Entry[index].ID = &f.RSS.Channel.Item[index].GUID.Content
 		f.Entry[index].Link = &f.RSS.Channel.Item[index].Link
-		f.Entry[index].Published = &f.RSS.Channel.Item[index].PubDate
+		f.Entry[index].Published = fallback(&f.RSS.Channel.Item[index].PubDate,
+			dc.Date, dc.Issued, dc.Created)
 		f.Entry[index].Source.Title = &f.RSS.Channel.Item[index].Source.Title
+		f.Entry[index].Chapters = &f.RSS.Channel.Item[index].Podcast.Chapters.URL
 		f.Entry[index].Source.URL = &f.RSS.Channel.Item[index].Source.URL
+		f.Entry[index].Content = htmlContent(&f.RSS.Channel.Item[index].Content)
+
+		// An item has at most one enclosure
+		enclosure := &f.RSS.Channel.Item[index].Enclosure
+		if enclosure.URL != "" {
+			f.Entry[index].Enclosures = []Enclosure{{
+				Length:   &enclosure.Length,
+				MIMEType: &enclosure.Type,
+				URL:      &enclosure.URL,
+			}}
+		}
+
+		transcripts := f.RSS.Channel.Item[index].Podcast.Transcripts
+		for index2 := range transcripts {
+			f.Entry[index].Transcripts = append(f.Entry[index].Transcripts,
+				Transcript{Language: &transcripts[index2].Language,
+					MIMEType: &transcripts[index2].Type,
+					URL:      &transcripts[index2].URL})
+		}
+
+		m := &f.RSS.Channel.Item[index].Media
+		f.Entry[index].Enclosures = mediaEnclosures(
+			f.Entry[index].Enclosures, m)
+		f.Entry[index].Thumbnails = mediaThumbnails(m)
 
 		nbCat := len(f.RSS.Channel.Item[index].Category)
 		f.Entry[index].Category = make([]*string, nbCat)
@@ -78,15 +274,20 @@ func (f *Feed) parseRSS() {
 			ptr = &f.RSS.Channel.Item[index].Category[index2].Content
 			f.Entry[index].Category[index2] = ptr
 		}
+		if nbCat == 0 {
+			f.Entry[index].Category = subjects(dc.Subject)
+		}
 	}
 }
 
 func (f *Feed) parseAtom() {
+	// The Dublin Core elements are used when the Atom ones are absent
+	dc := &f.Atom.DublinCore
+
 	if len(f.Atom.Author) > 0 {
 		f.Author = &f.Atom.Author[0].Name
 	} else {
-		tmp := "" // Avoid nil pointer
-		f.Author = &tmp
+		f.Author = fallback(emptyString(), dc.Creator)
 	}
 
 	if len(f.Atom.Link) > 0 {
@@ -97,40 +298,71 @@ func (f *Feed) parseAtom() {
 	}
 
 	f.Description = &f.Atom.Subtitle.Content
+	f.Extensions = f.Atom.Extensions
+	f.Modules = f.Atom.Modules
 	f.Generator = &f.Atom.Generator.Content
-	f.Language = &f.Atom.CommonAttributes.Lang
+	f.Language = fallback(&f.Atom.CommonAttributes.Lang, dc.Language)
 	f.Logo = &f.Atom.Logo.URI
-	f.Rights = &f.Atom.Rights.Content
+	f.Rights = fallback(&f.Atom.Rights.Content, dc.Rights)
 	f.Title = &f.Atom.Title.Content
-	f.Updated = &f.Atom.Updated.DateTime
+	f.Updated = fallback(&f.Atom.Updated.DateTime, dc.Modified, dc.Date)
+	f.Version = f.Atom.Version
 
 	f.Category = make([]*string, len(f.Atom.Category))
 	for index := range f.Category {
 		f.Category[index] = &f.Atom.Category[index].Content
 	}
+	if len(f.Category) == 0 {
+		f.Category = subjects(dc.Subject)
+	}
 
 	f.Entry = make([]Entry, len(f.Atom.Entry))
 	for index := range f.Entry {
+		dc := &f.Atom.Entry[index].DublinCore
+
 		if len(f.Atom.Entry[index].Author) > 0 {
 			f.Entry[index].Author = &f.Atom.Entry[index].Author[0].Name
 		} else {
-			tmp := "" // Avoid nil pointer
-			f.Author = &tmp
+			f.Entry[index].Author = fallback(emptyString(), dc.Creator)
 		}
 
 		if len(f.Atom.Entry[index].Link) > 0 {
 			f.Entry[index].Link = &f.Atom.Entry[index].Link[0].Href
 		} else {
 			tmp := "" // Avoid nil pointer
-			f.Link = &tmp
+			f.Entry[index].Link = &tmp
 		}
 
 		f.Entry[index].Title = &f.Atom.Entry[index].Title.Content
 		f.Entry[index].Description = &f.Atom.Entry[index].Summary.Content
+		f.Entry[index].Extensions = f.Atom.Entry[index].Extensions
+		f.Entry[index].Modules = f.Atom.Entry[index].Modules
 		f.Entry[index].ID = &f.Atom.Entry[index].ID.URI
-		f.Entry[index].Published = &f.Atom.Entry[index].Published.DateTime
+		f.Entry[index].Published = fallback(
+			&f.Atom.Entry[index].Published.DateTime, dc.Date, dc.Issued,
+			dc.Created)
 		f.Entry[index].Source.Title = &f.Atom.Entry[index].Source.Title.Content
+		f.Entry[index].Chapters = emptyString()
 		f.Entry[index].Source.URL = &f.Atom.Entry[index].Source.ID.URI
+		f.Entry[index].Content = atomContent(&f.Atom.Entry[index].Content)
+
+		for index2 := range f.Atom.Entry[index].Link {
+			link := &f.Atom.Entry[index].Link[index2]
+			if link.Rel != "enclosure" {
+				continue
+			}
+
+			// length is an attribute of text, a size which is not an integer
+			// is unknown
+			length, _ := strconv.ParseInt(strings.TrimSpace(link.Length), 10, 64)
+			f.Entry[index].Enclosures = append(f.Entry[index].Enclosures,
+				Enclosure{Length: &length, MIMEType: &link.Type, URL: &link.Href})
+		}
+
+		m := &f.Atom.Entry[index].Media
+		f.Entry[index].Enclosures = mediaEnclosures(
+			f.Entry[index].Enclosures, m)
+		f.Entry[index].Thumbnails = mediaThumbnails(m)
 
 		nbCat := len(f.Atom.Entry[index].Category)
 		f.Entry[index].Category = make([]*string, nbCat)
@@ -139,5 +371,284 @@ func (f *Feed) parseAtom() {
 			ptr = &f.Atom.Entry[index].Category[index2].Content
 			f.Entry[index].Category[index2] = ptr
 		}
+		if nbCat == 0 {
+			f.Entry[index].Category = subjects(dc.Subject)
+		}
+	}
+}
+
+func (f *Feed) parseRDF() {
+	// RSS 1.0 has no core element for these fields, the feeds use the Dublin
+	// Core module
+	// source : http://web.resource.org/rss/1.0/modules/dc/
+	dc := &f.RDF.Channel.DublinCore
+
+	f.Author = fallback(emptyString(), dc.Creator)
+	f.Generator = emptyString()
+	f.Language = fallback(emptyString(), dc.Language)
+	f.Rights = fallback(emptyString(), dc.Rights)
+	f.Updated = fallback(emptyString(), dc.Date, dc.Modified)
+
+	f.Description = &f.RDF.Channel.Description
+	f.Link = &f.RDF.Channel.Link
+	f.Logo = &f.RDF.Image.URL
+	f.Title = &f.RDF.Channel.Title
+	f.Category = subjects(dc.Subject)
+	f.Modules = f.RDF.Channel.Modules
+	f.Version = f.RDF.Version
+
+	f.Entry = make([]Entry, len(f.RDF.Item))
+	for index := range f.Entry {
+		dc := &f.RDF.Item[index].DublinCore
+
+		f.Entry[index].Author = fallback(emptyString(), dc.Creator)
+		f.Entry[index].Title = &f.RDF.Item[index].Title
+		f.Entry[index].Description = &f.RDF.Item[index].Description
+		f.Entry[index].ID = &f.RDF.Item[index].About
+		f.Entry[index].Link = &f.RDF.Item[index].Link
+		f.Entry[index].Modules = f.RDF.Item[index].Modules
+		f.Entry[index].Published = fallback(emptyString(), dc.Date, dc.Issued,
+			dc.Created)
+		f.Entry[index].Source.Title = emptyString()
+		f.Entry[index].Chapters = emptyString()
+		f.Entry[index].Source.URL = emptyString()
+		f.Entry[index].Category = subjects(dc.Subject)
+		f.Entry[index].Content = htmlContent(&f.RDF.Item[index].Content)
+	}
+}
+
+// emptyString returns a new empty string, used to avoid nil pointers when a
+// format has no equivalent of a field
+func emptyString() *string {
+	tmp := ""
+	return &tmp
+}
+
+// fallback returns value, or the first of the Dublin Core values when value is
+// empty. The values are looked in order.
+func fallback(value *string, values ...[]string) *string {
+	if *value != "" {
+		return value
+	}
+
+	for _, v := range values {
+		if len(v) > 0 {
+			return &v[0]
+		}
+	}
+
+	return value
+}
+
+// subjects returns the Dublin Core subjects, the categories of a format which
+// has none
+func subjects(values []string) []*string {
+	res := make([]*string, len(values))
+	for index := range values {
+		res[index] = &values[index]
+	}
+
+	return res
+}
+
+// htmlContent returns the content of an entry from its escaped HTML, e.g. a
+// RSS content:encoded element
+func htmlContent(value *string) Content {
+	c := Content{MIMEType: emptyString(), Src: emptyString(), Value: value}
+	if *value != "" {
+		c.Type = ContentTypeHTML
+	}
+
+	return c
+}
+
+// atomContent returns the content of an entry from its atom:content, the
+// inline text/plain and text/html media types are handled like the text and
+// html types
+// source : https://tools.ietf.org/html/rfc4287#section-4.1.3
+func atomContent(content *atom.Content) Content {
+	c := Content{MIMEType: emptyString(), Src: &content.Src,
+		Value: &content.Content}
+
+	switch {
+	case content.Src != "":
+		c.MIMEType = &content.Type
+		c.Type = ContentTypeSrc
+	case content.Content == "":
+		c.Type = ContentTypeNone
+	case content.Type == "text" || content.Type == "text/plain":
+		c.Type = ContentTypeText
+	case content.Type == "html" || content.Type == "text/html":
+		c.Type = ContentTypeHTML
+	case content.Type == "xhtml":
+		c.Type = ContentTypeXHTML
+	default:
+		c.MIMEType = &content.Type
+		c.Type = ContentTypeMedia
+	}
+
+	return c
+}
+
+// mediaEnclosures appends the Media RSS contents of an entry, alone or in
+// groups, to its enclosures. A content whose URL is already an enclosure, or
+// without URL, is not appended.
+func mediaEnclosures(enclosures []Enclosure, m *media.Media) []Enclosure {
+	contents := make([]*media.Content, 0, len(m.Contents))
+	for index := range m.Contents {
+		contents = append(contents, &m.Contents[index])
+	}
+	for index := range m.Groups {
+		for index2 := range m.Groups[index].Contents {
+			contents = append(contents, &m.Groups[index].Contents[index2])
+		}
+	}
+
+	for _, c := range contents {
+		known := c.URL == ""
+		for _, e := range enclosures {
+			known = known || *e.URL == c.URL
+		}
+
+		if !known {
+			enclosures = append(enclosures, Enclosure{Length: &c.FileSize,
+				MIMEType: &c.Type, URL: &c.URL})
+		}
+	}
+
+	return enclosures
+}
+
+// mediaThumbnails returns the Media RSS thumbnails of an entry : the ones of
+// the item, then the ones of its contents and of its groups. A thumbnail is
+// listed once, the ones without URL are dropped.
+func mediaThumbnails(m *media.Media) []Thumbnail {
+	elements := []*media.Elements{&m.Elements}
+	for index := range m.Contents {
+		elements = append(elements, &m.Contents[index].Elements)
+	}
+	for index := range m.Groups {
+		elements = append(elements, &m.Groups[index].Elements)
+		for index2 := range m.Groups[index].Contents {
+			elements = append(elements,
+				&m.Groups[index].Contents[index2].Elements)
+		}
+	}
+
+	var res []Thumbnail
+	seen := make(map[string]bool)
+	for _, e := range elements {
+		for index := range e.Thumbnails {
+			t := &e.Thumbnails[index]
+			if t.URL == "" || seen[t.URL] {
+				continue
+			}
+
+			seen[t.URL] = true
+			res = append(res, Thumbnail{Height: &t.Height, URL: &t.URL,
+				Width: &t.Width})
+		}
+	}
+
+	return res
+}
+
+func (f *Feed) parseJSON() {
+	if len(f.JSON.Authors) > 0 {
+		f.Author = &f.JSON.Authors[0].Name
+	} else {
+		f.Author = emptyString()
+	}
+
+	// JSON Feed has no core field for these fields
+	f.Generator = emptyString()
+	f.Rights = emptyString()
+	f.Updated = emptyString()
+
+	f.Description = &f.JSON.Description
+	f.Language = &f.JSON.Language
+	f.Link = &f.JSON.HomePageURL
+	f.Logo = &f.JSON.Icon
+	f.Title = &f.JSON.Title
+	f.Category = make([]*string, 0)
+	f.Version = f.JSON.ShortVersion()
+
+	f.Entry = make([]Entry, len(f.JSON.Items))
+	for index := range f.Entry {
+		item := &f.JSON.Items[index]
+
+		if len(item.Authors) > 0 {
+			f.Entry[index].Author = &item.Authors[0].Name
+		} else {
+			f.Entry[index].Author = emptyString()
+		}
+
+		// The summary is the closest field of a description, the content is
+		// used when the summary is absent like a RSS description would be
+		switch {
+		case item.Summary != "":
+			f.Entry[index].Description = &item.Summary
+		case item.ContentHTML != "":
+			f.Entry[index].Description = &item.ContentHTML
+		default:
+			f.Entry[index].Description = &item.ContentText
+		}
+
+		f.Entry[index].Title = &item.Title
+		f.Entry[index].ID = &item.ID
+		f.Entry[index].Link = &item.URL
+		f.Entry[index].Published = &item.DatePublished
+		f.Entry[index].Source.Title = emptyString()
+		f.Entry[index].Chapters = emptyString()
+		f.Entry[index].Source.URL = &item.ExternalURL
+
+		if item.ContentHTML != "" {
+			f.Entry[index].Content = htmlContent(&item.ContentHTML)
+		} else {
+			f.Entry[index].Content = htmlContent(&item.ContentText)
+			if item.ContentText != "" {
+				f.Entry[index].Content.Type = ContentTypeText
+			}
+		}
+
+		for index2 := range item.Attachments {
+			attachment := &item.Attachments[index2]
+			f.Entry[index].Enclosures = append(f.Entry[index].Enclosures,
+				Enclosure{Length: &attachment.SizeInBytes,
+					MIMEType: &attachment.MIMEType, URL: &attachment.URL})
+		}
+
+		f.Entry[index].Category = make([]*string, len(item.Tags))
+		for index2 := range f.Entry[index].Category {
+			f.Entry[index].Category[index2] = &item.Tags[index2]
+		}
+	}
+}
+
+// bom is the UTF-8 byte order mark
+var bom = []byte("\xef\xbb\xbf")
+
+// firstByte returns the first significant byte of the document, after the
+// byte order mark and the white spaces, without consuming it. A document with
+// a very long run of white spaces is not looked further, 0 is returned.
+func firstByte(br *bufio.Reader) (byte, error) {
+	for n := 1; ; n++ {
+		b, err := br.Peek(n)
+		switch {
+		case err == io.EOF:
+			return 0, ErrEmptyDocument
+		case err == bufio.ErrBufferFull:
+			return 0, nil
+		case err != nil:
+			return 0, err
+		}
+
+		if n <= len(bom) && bytes.HasPrefix(bom, b) {
+			continue
+		}
+
+		if c := b[n-1]; c != ' ' && c != '\t' && c != '\r' && c != '\n' {
+			return c, nil
+		}
 	}
 }
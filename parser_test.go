@@ -0,0 +1,748 @@
+// This file is part of clutch.
+//
+// clutch is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// clutch is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with Foobar.  If not, see <http://www.gnu.org/licenses/>.
+
+package clutch
+
+import (
+	"encoding/json"
+	"encoding/xml"
+	"errors"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"testing/iotest"
+	"time"
+
+	"github.com/racam/clutch/atom"
+	"github.com/racam/clutch/rdf"
+	"github.com/racam/clutch/rss"
+)
+
+func TestParseFeedType(t *testing.T) {
+	var files = []struct {
+		filename string   // input file
+		feedType FeedType // expected type
+		version  string   // expected version
+	}{
+		{"testdata/rss/unit_01_IsDeclared.xml", FeedTypeRSS, "2.0"},
+		{"testdata/rss/unit_04_IsDeclared.xml", FeedTypeRSS, "0.91"},
+		{"testdata/atom/unit_01_IsDeclared.xml", FeedTypeAtom, "1.0"},
+		{"testdata/rdf/unit_05_parse.xml", FeedTypeRDF, "1.0"},
+		{"testdata/jsonfeed/unit_05_parse.json", FeedTypeJSON, "1.1"},
+		{"testdata/jsonfeed/unit_06_parse_10.json", FeedTypeJSON, "1.0"},
+	}
+
+	for _, file := range files {
+		data, err := ioutil.ReadFile(file.filename)
+		if err != nil {
+			t.Errorf("[Clutch][Unit][Parse] file '%s' : is missing",
+				file.filename)
+			continue
+		}
+
+		f, err := Parse(data)
+		if err != nil {
+			t.Errorf("[Clutch][Unit][Parse] file '%s' : %s", file.filename, err)
+			continue
+		}
+
+		if f.FeedType != file.feedType || f.Version != file.version {
+			t.Errorf("[Clutch][Unit][Parse] file '%s' : expected (%d, %s), "+
+				"actual (%d, %s)", file.filename, file.feedType, file.version,
+				f.FeedType, f.Version)
+		}
+	}
+}
+
+func TestParseRDF(t *testing.T) {
+	data, err := ioutil.ReadFile("testdata/rdf/unit_05_parse.xml")
+	if err != nil {
+		t.Fatalf("[Clutch][Unit][Parse] %s", err)
+	}
+
+	f, err := Parse(data)
+	if err != nil {
+		t.Fatalf("[Clutch][Unit][Parse] %s", err)
+	}
+
+	if *f.Title != "Channel title" || *f.Logo != "http://example.org/logo.png" {
+		t.Errorf("[Clutch][Unit][Parse] Feed : unexpected title '%s' or "+
+			"logo '%s'", *f.Title, *f.Logo)
+	}
+
+	if len(f.Entry) != 2 {
+		t.Fatalf("[Clutch][Unit][Parse] Entry : expected 2 entries, actual %d",
+			len(f.Entry))
+	}
+
+	if *f.Entry[1].ID != "http://example.org/2" || *f.Entry[1].Author != "" {
+		t.Errorf("[Clutch][Unit][Parse] Entry : unexpected id '%s' or "+
+			"author '%s'", *f.Entry[1].ID, *f.Entry[1].Author)
+	}
+}
+
+func TestParseRSSEnclosure(t *testing.T) {
+	data, err := ioutil.ReadFile("testdata/rss/unit_07_parse_enclosure.xml")
+	if err != nil {
+		t.Fatalf("[Clutch][Unit][Parse] %s", err)
+	}
+
+	f, err := Parse(data)
+	if err != nil {
+		t.Fatalf("[Clutch][Unit][Parse] %s", err)
+	}
+
+	if len(f.Entry) != 2 || len(f.Entry[1].Enclosures) != 0 {
+		t.Fatalf("[Clutch][Unit][Parse] Entry : unexpected %+v", f.Entry)
+	}
+
+	if len(f.Entry[0].Enclosures) != 1 {
+		t.Fatalf("[Clutch][Unit][Parse] Enclosure : expected 1 enclosure, "+
+			"actual %d", len(f.Entry[0].Enclosures))
+	}
+
+	e := f.Entry[0].Enclosures[0]
+	if *e.URL != "http://example.org/1.mp3" || *e.Length != 24986239 ||
+		*e.MIMEType != "audio/mpeg" {
+		t.Errorf("[Clutch][Unit][Parse] Enclosure : unexpected url '%s', "+
+			"length %d or type '%s'", *e.URL, *e.Length, *e.MIMEType)
+	}
+}
+
+func TestParseAtomEnclosure(t *testing.T) {
+	data, err := ioutil.ReadFile("testdata/atom/unit_27_parse_enclosure.xml")
+	if err != nil {
+		t.Fatalf("[Clutch][Unit][Parse] %s", err)
+	}
+
+	f, err := Parse(data)
+	if err != nil {
+		t.Fatalf("[Clutch][Unit][Parse] %s", err)
+	}
+
+	if len(f.Entry) != 1 || len(f.Entry[0].Enclosures) != 2 {
+		t.Fatalf("[Clutch][Unit][Parse] Enclosure : expected 2 enclosures, "+
+			"actual %+v", f.Entry)
+	}
+
+	e := f.Entry[0].Enclosures[0]
+	if *e.URL != "http://example.org/1.mp3" || *e.Length != 24986239 ||
+		*e.MIMEType != "audio/mpeg" {
+		t.Errorf("[Clutch][Unit][Parse] Enclosure : unexpected url '%s', "+
+			"length %d or type '%s'", *e.URL, *e.Length, *e.MIMEType)
+	}
+
+	// The length is optional in Atom
+	e = f.Entry[0].Enclosures[1]
+	if *e.URL != "http://example.org/1.ogg" || *e.Length != 0 {
+		t.Errorf("[Clutch][Unit][Parse] Enclosure : unexpected url '%s' or "+
+			"length %d", *e.URL, *e.Length)
+	}
+}
+
+func TestParseRSSContent(t *testing.T) {
+	data, err := ioutil.ReadFile("testdata/rss/unit_08_parse_content.xml")
+	if err != nil {
+		t.Fatalf("[Clutch][Unit][Parse] %s", err)
+	}
+
+	f, err := Parse(data)
+	if err != nil {
+		t.Fatalf("[Clutch][Unit][Parse] %s", err)
+	}
+
+	if len(f.Entry) != 2 {
+		t.Fatalf("[Clutch][Unit][Parse] Entry : expected 2 entries, actual %d",
+			len(f.Entry))
+	}
+
+	c := f.Entry[0].Content
+	if c.Type != ContentTypeHTML || *c.Value != "<p>Full article</p>" ||
+		*f.Entry[0].Description != "Teaser" {
+		t.Errorf("[Clutch][Unit][Parse] Content : unexpected %s '%s'", c.Type,
+			*c.Value)
+	}
+
+	c = f.Entry[1].Content
+	if c.Type != ContentTypeNone || *c.Value != "" {
+		t.Errorf("[Clutch][Unit][Parse] Content : unexpected %s '%s'", c.Type,
+			*c.Value)
+	}
+}
+
+func TestParseAtomContent(t *testing.T) {
+	data, err := ioutil.ReadFile("testdata/atom/unit_28_parse_content.xml")
+	if err != nil {
+		t.Fatalf("[Clutch][Unit][Parse] %s", err)
+	}
+
+	f, err := Parse(data)
+	if err != nil {
+		t.Fatalf("[Clutch][Unit][Parse] %s", err)
+	}
+
+	var expected = []struct {
+		contentType ContentType
+		value       string
+		mimeType    string
+		src         string
+	}{
+		{ContentTypeText, "Plain <text>", "", ""},
+		{ContentTypeHTML, "<p>HTML</p>", "", ""},
+		{ContentTypeXHTML, `<div xmlns="http://www.w3.org/1999/xhtml">` +
+			`<p>XHTML</p></div>`, "", ""},
+		{ContentTypeSrc, "", "video/mp4", "http://example.org/4.mp4"},
+		{ContentTypeMedia, "iVBORw0KGgo=", "image/png", ""},
+		{ContentTypeNone, "", "", ""},
+	}
+
+	if len(f.Entry) != len(expected) {
+		t.Fatalf("[Clutch][Unit][Parse] Entry : expected %d entries, "+
+			"actual %d", len(expected), len(f.Entry))
+	}
+
+	for index, e := range expected {
+		c := f.Entry[index].Content
+		if c.Type != e.contentType || *c.Value != e.value ||
+			*c.MIMEType != e.mimeType || *c.Src != e.src {
+			t.Errorf("[Clutch][Unit][Parse] Content %d : expected %s '%s' "+
+				"('%s', '%s'), actual %s '%s' ('%s', '%s')", index,
+				e.contentType, e.value, e.mimeType, e.src, c.Type, *c.Value,
+				*c.MIMEType, *c.Src)
+		}
+	}
+}
+
+func TestParseDublinCore(t *testing.T) {
+	var files = []struct {
+		filename  string
+		author    string // of the feed
+		updated   string // of the feed
+		published string // of the first entry
+		category  string // first of the first entry
+	}{
+		{"testdata/rss/unit_09_parse_dublincore.xml", "John Doe",
+			"2006-01-02T15:04:05Z", "2006-01-01T15:04:05Z", "feeds"},
+		{"testdata/atom/unit_29_parse_dublincore.xml", "John Doe",
+			"2006-01-02T15:04:05Z", "2006-01-01T15:04:05Z", "go"},
+		{"testdata/rdf/unit_05_parse.xml", "John Doe", "",
+			"2006-01-02T15:04:05Z", "go"},
+	}
+
+	for _, file := range files {
+		f := parseFile(t, file.filename)
+
+		if *f.Author != file.author || *f.Updated != file.updated {
+			t.Errorf("[Clutch][Unit][Parse] file '%s' : expected author '%s' "+
+				"and updated '%s', actual '%s' and '%s'", file.filename,
+				file.author, file.updated, *f.Author, *f.Updated)
+		}
+
+		e := f.Entry[0]
+		if *e.Published != file.published || len(e.Category) != 1 ||
+			*e.Category[0] != file.category {
+			t.Errorf("[Clutch][Unit][Parse] file '%s' : expected published "+
+				"'%s' and category '%s', actual '%s' and %d categories",
+				file.filename, file.published, file.category, *e.Published,
+				len(e.Category))
+		}
+	}
+
+	// The RSS elements win over the Dublin Core ones
+	f := parseFile(t, "testdata/rss/unit_09_parse_dublincore.xml")
+	e := f.Entry[1]
+	if *e.Author != "jane@example.org (Jane Doe)" ||
+		*e.Published != "Mon, 02 Jan 2006 15:04:05 GMT" ||
+		*e.Category[0] != "rss" {
+		t.Errorf("[Clutch][Unit][Parse] Entry : unexpected %+v", e)
+	}
+}
+
+func TestParseMedia(t *testing.T) {
+	f := parseFile(t, "testdata/rss/unit_10_parse_media.xml")
+	e := f.Entry[0]
+
+	if len(e.Enclosures) != 2 ||
+		*e.Enclosures[0].URL != "http://example.org/1.mp4" ||
+		*e.Enclosures[1].URL != "http://example.org/1.webm" ||
+		*e.Enclosures[1].Length != 800 ||
+		*e.Enclosures[1].MIMEType != "video/webm" {
+		t.Errorf("[Clutch][Unit][Parse] Enclosure : unexpected %+v",
+			e.Enclosures)
+	}
+
+	if len(e.Thumbnails) != 2 {
+		t.Fatalf("[Clutch][Unit][Parse] Thumbnail : expected 2 thumbnails, "+
+			"actual %d", len(e.Thumbnails))
+	}
+
+	th := e.Thumbnails[1]
+	if *th.URL != "http://example.org/1-small.jpg" || *th.Width != 60 ||
+		*th.Height != 45 {
+		t.Errorf("[Clutch][Unit][Parse] Thumbnail : unexpected url '%s', "+
+			"width %d or height %d", *th.URL, *th.Width, *th.Height)
+	}
+
+	f = parseFile(t, "testdata/atom/unit_30_parse_media.xml")
+	e = f.Entry[0]
+
+	if len(e.Enclosures) != 1 ||
+		*e.Enclosures[0].URL != "http://example.org/v/1" ||
+		*e.Enclosures[0].Length != 0 {
+		t.Errorf("[Clutch][Unit][Parse] Enclosure : unexpected %+v",
+			e.Enclosures)
+	}
+
+	if len(e.Thumbnails) != 1 ||
+		*e.Thumbnails[0].URL != "http://example.org/1.jpg" ||
+		*e.Thumbnails[0].Width != 480 {
+		t.Errorf("[Clutch][Unit][Parse] Thumbnail : unexpected %+v",
+			e.Thumbnails)
+	}
+
+	if f.Atom.Entry[0].Media.Groups[0].Community.Statistics.Views != 345 {
+		t.Errorf("[Clutch][Unit][Parse] Community : unexpected %+v",
+			f.Atom.Entry[0].Media.Groups[0].Community)
+	}
+}
+
+func TestParseITunes(t *testing.T) {
+	f := parseFile(t, "testdata/rss/unit_11_parse_itunes.xml")
+
+	if *f.Author != "John Doe" || *f.Logo != "http://example.org/podcast.jpg" {
+		t.Errorf("[Clutch][Unit][Parse] Feed : expected the iTunes author "+
+			"and image, actual '%s' and '%s'", *f.Author, *f.Logo)
+	}
+
+	if f.ITunes == nil || len(f.ITunes.Categories) != 1 ||
+		f.ITunes.Categories[0].Categories[0].Text != "Tech News" {
+		t.Fatalf("[Clutch][Unit][Parse] ITunes : unexpected %+v", f.ITunes)
+	}
+
+	e := f.Entry[0]
+	if *e.Author != "Jane Doe" || e.ITunes == nil ||
+		e.ITunes.Duration != 30*time.Minute || e.ITunes.Episode != 1 ||
+		e.ITunes.Explicit == nil || !*e.ITunes.Explicit {
+		t.Errorf("[Clutch][Unit][Parse] Entry : unexpected %+v", e.ITunes)
+	}
+
+	// The RSS author wins over the iTunes one
+	if *f.Entry[1].Author != "john@example.org (John Doe)" {
+		t.Errorf("[Clutch][Unit][Parse] Entry : unexpected author '%s'",
+			*f.Entry[1].Author)
+	}
+
+	f = parseFile(t, "testdata/atom/unit_27_parse_enclosure.xml")
+	if f.ITunes != nil || f.Entry[0].ITunes != nil {
+		t.Errorf("[Clutch][Unit][Parse] ITunes : expected nil for Atom")
+	}
+}
+
+func TestParsePodcast(t *testing.T) {
+	f := parseFile(t, "testdata/rss/unit_12_parse_podcast.xml")
+
+	if len(f.Entry) != 2 || len(f.RSS.Channel.LiveItem) != 1 {
+		t.Fatalf("[Clutch][Unit][Parse] Entry : expected 2 entries and 1 live "+
+			"item, actual %d and %d", len(f.Entry),
+			len(f.RSS.Channel.LiveItem))
+	}
+
+	e := f.Entry[0]
+	if *e.Chapters != "https://example.org/1-chapters.json" {
+		t.Errorf("[Clutch][Unit][Parse] Chapters : unexpected '%s'",
+			*e.Chapters)
+	}
+
+	if len(e.Transcripts) != 2 {
+		t.Fatalf("[Clutch][Unit][Parse] Transcript : expected 2 transcripts, "+
+			"actual %d", len(e.Transcripts))
+	}
+
+	tr := e.Transcripts[0]
+	if *tr.URL != "https://example.org/1.vtt" || *tr.MIMEType != "text/vtt" ||
+		*tr.Language != "en" {
+		t.Errorf("[Clutch][Unit][Parse] Transcript : unexpected url '%s', "+
+			"type '%s' or language '%s'", *tr.URL, *tr.MIMEType, *tr.Language)
+	}
+
+	if *f.Entry[1].Chapters != "" || len(f.Entry[1].Transcripts) != 0 {
+		t.Errorf("[Clutch][Unit][Parse] Entry : unexpected %+v", f.Entry[1])
+	}
+
+	f = parseFile(t, "testdata/atom/unit_27_parse_enclosure.xml")
+	if *f.Entry[0].Chapters != "" {
+		t.Errorf("[Clutch][Unit][Parse] Chapters : expected empty for Atom")
+	}
+}
+
+func TestParseExtensions(t *testing.T) {
+	const feedburner = "http://rssnamespace.org/feedburner/ext/1.0"
+
+	var files = []string{"testdata/rss/unit_13_parse_extensions.xml",
+		"testdata/atom/unit_31_parse_extensions.xml"}
+
+	for _, filename := range files {
+		f := parseFile(t, filename)
+
+		info := f.Extensions.Get(feedburner, "info")
+		if len(info) != 1 {
+			t.Fatalf("[Clutch][Unit][Parse] file '%s' : expected "+
+				"feedburner:info, actual %+v", filename, f.Extensions)
+		}
+
+		if uri, _ := info[0].Attr("uri"); uri != "example" {
+			t.Errorf("[Clutch][Unit][Parse] file '%s' : unexpected uri '%s'",
+				filename, uri)
+		}
+
+		e := f.Entry[0]
+		v := e.Extensions.Value(feedburner, "origLink")
+		if v != "http://example.org/1" {
+			t.Errorf("[Clutch][Unit][Parse] file '%s' : unexpected origLink "+
+				"'%s'", filename, v)
+		}
+	}
+
+	f := parseFile(t, "testdata/rss/unit_13_parse_extensions.xml")
+	e := f.Entry[0]
+	if e.Extensions.Value("http://purl.org/rss/1.0/modules/slash/",
+		"comments") != "42" || len(e.Extensions) != 2 {
+		t.Errorf("[Clutch][Unit][Parse] Extensions : unexpected %+v",
+			e.Extensions)
+	}
+
+	f = parseFile(t, "testdata/atom/unit_31_parse_extensions.xml")
+	if v := f.Atom.Entry[0].Source.Extensions.Value(feedburner,
+		"origLink"); v != "http://example.org/" {
+		t.Errorf("[Clutch][Unit][Parse] Source : unexpected origLink '%s'", v)
+	}
+}
+
+func TestParseWithURL(t *testing.T) {
+	filename := "testdata/atom/unit_33_resolve_urls.xml"
+	data, err := ioutil.ReadFile(filename)
+	if err != nil {
+		t.Fatalf("[Clutch][Unit][Parse] file '%s' : is missing", filename)
+	}
+
+	f, err := ParseWithURL(data, "http://example.org/feeds/atom.xml")
+	if err != nil {
+		t.Fatalf("[Clutch][Unit][Parse] file '%s' : %s", filename, err)
+	}
+
+	if *f.Link != "http://example.org/feeds/blog/index.html" ||
+		*f.Logo != "http://example.org/images/logo.png" {
+		t.Errorf("[Clutch][Unit][Parse] Feed : unexpected link '%s' or logo "+
+			"'%s'", *f.Link, *f.Logo)
+	}
+
+	e := f.Entry[0]
+	if *e.Link != "http://example.org/feeds/blog/2006/post.html" ||
+		!strings.Contains(*e.Content.Value,
+			`href="http://example.org/feeds/blog/2006/a.html?x=1&amp;y=2"`) {
+		t.Errorf("[Clutch][Unit][Parse] Entry : unexpected link '%s' or "+
+			"content %s", *e.Link, *e.Content.Value)
+	}
+
+	// without the document URL, the xml:base attributes are used alone
+	f = parseFile(t, filename)
+	if *f.Link != "blog/index.html" ||
+		*f.Entry[1].Content.Src != "http://cdn.example.org/media/video.mp4" {
+		t.Errorf("[Clutch][Unit][Parse] Parse : unexpected link '%s' or src "+
+			"'%s'", *f.Link, *f.Entry[1].Content.Src)
+	}
+
+	if _, err := ParseWithURL(data, "http://[::1"); err == nil {
+		t.Errorf("[Clutch][Unit][Parse] invalid URL : expected an error")
+	}
+}
+
+func TestParseJSON(t *testing.T) {
+	data, err := ioutil.ReadFile("testdata/jsonfeed/unit_05_parse.json")
+	if err != nil {
+		t.Fatalf("[Clutch][Unit][Parse] %s", err)
+	}
+
+	f, err := Parse(data)
+	if err != nil {
+		t.Fatalf("[Clutch][Unit][Parse] %s", err)
+	}
+
+	if *f.Author != "Jane Doe" || *f.Link != "https://example.org/" {
+		t.Errorf("[Clutch][Unit][Parse] Feed : unexpected author '%s' or "+
+			"link '%s'", *f.Author, *f.Link)
+	}
+
+	if len(f.Entry) != 1 {
+		t.Fatalf("[Clutch][Unit][Parse] Entry : expected 1 entry, actual %d",
+			len(f.Entry))
+	}
+
+	e := f.Entry[0]
+	if *e.Description != "Item 1 summary" || len(e.Category) != 2 ||
+		*e.Category[1] != "feed" {
+		t.Errorf("[Clutch][Unit][Parse] Entry : unexpected %+v", e)
+	}
+
+	if e.Content.Type != ContentTypeHTML {
+		t.Errorf("[Clutch][Unit][Parse] Content : unexpected %s",
+			e.Content.Type)
+	}
+
+	if len(e.Enclosures) != 1 || *e.Enclosures[0].Length != 1234 ||
+		*e.Enclosures[0].MIMEType != "audio/mpeg" {
+		t.Errorf("[Clutch][Unit][Parse] Enclosure : unexpected %+v",
+			e.Enclosures)
+	}
+}
+
+func TestFailParse(t *testing.T) {
+	var files = []string{
+		"testdata/atom/unit_04_not_atom.xml",
+		"testdata/jsonfeed/unit_04_IsDeclared.json",
+	}
+
+	for _, filename := range files {
+		data, err := ioutil.ReadFile(filename)
+		if err != nil {
+			t.Errorf("[Clutch][Unit][Parse] file '%s' : is missing", filename)
+			continue
+		}
+
+		f, err := Parse(data)
+		if f != nil || err == nil {
+			t.Errorf("[Clutch][Unit][Parse] file '%s' : expected an error",
+				filename)
+		}
+	}
+}
+
+// TestParseReader reads the documents one byte at a time, so the parsing
+// MUST NOT expect the whole document from a single Read
+func TestParseReader(t *testing.T) {
+	var files = []struct {
+		filename string   // input file
+		feedType FeedType // expected type
+	}{
+		{"testdata/rss/unit_01_IsDeclared.xml", FeedTypeRSS},
+		{"testdata/atom/unit_01_IsDeclared.xml", FeedTypeAtom},
+		{"testdata/atom/unit_05_IsDeclared.xml", FeedTypeAtom},
+		{"testdata/rdf/unit_05_parse.xml", FeedTypeRDF},
+		{"testdata/jsonfeed/unit_05_parse.json", FeedTypeJSON},
+	}
+
+	for _, file := range files {
+		fd, err := os.Open(file.filename)
+		if err != nil {
+			t.Errorf("[Clutch][Unit][ParseReader] file '%s' : is missing",
+				file.filename)
+			continue
+		}
+
+		f, err := ParseReader(iotest.OneByteReader(fd))
+		fd.Close()
+		if err != nil {
+			t.Errorf("[Clutch][Unit][ParseReader] file '%s' : %s",
+				file.filename, err)
+			continue
+		}
+
+		if f.FeedType != file.feedType {
+			t.Errorf("[Clutch][Unit][ParseReader] file '%s' : expected %d, "+
+				"actual %d", file.filename, file.feedType, f.FeedType)
+		}
+	}
+}
+
+func TestParseReaderBOM(t *testing.T) {
+	var documents = []struct {
+		data     string   // input document
+		feedType FeedType // expected type
+	}{
+		{"\xef\xbb\xbf \n{\"version\": \"https://jsonfeed.org/version/1.1\"," +
+			"\"title\": \"a\", \"items\": []}", FeedTypeJSON},
+		{"\xef\xbb\xbf<?xml version=\"1.0\"?>\n<rss version=\"2.0\">" +
+			"<channel></channel></rss>", FeedTypeRSS},
+	}
+
+	for _, d := range documents {
+		f, err := ParseReader(strings.NewReader(d.data))
+		if err != nil {
+			t.Errorf("[Clutch][Unit][ParseReader] '%q' : %s", d.data, err)
+			continue
+		}
+
+		if f.FeedType != d.feedType {
+			t.Errorf("[Clutch][Unit][ParseReader] '%q' : expected %d, "+
+				"actual %d", d.data, d.feedType, f.FeedType)
+		}
+	}
+}
+
+// corpus returns the documents of the testdata directory
+func corpus(b *testing.B) [][]byte {
+	var res [][]byte
+
+	files, _ := filepath.Glob("testdata/*/*")
+	for _, file := range files {
+		if ext := filepath.Ext(file); ext != ".xml" && ext != ".json" {
+			continue
+		}
+
+		data, err := ioutil.ReadFile(file)
+		if err != nil {
+			b.Fatalf("[Clutch][Bench] %s", err)
+		}
+		res = append(res, data)
+	}
+
+	return res
+}
+
+// BenchmarkParse parses the testdata corpus, the root element is read once to
+// choose the decoder
+func BenchmarkParse(b *testing.B) {
+	documents := corpus(b)
+	b.ResetTimer()
+
+	for n := 0; n < b.N; n++ {
+		for _, data := range documents {
+			Parse(data)
+		}
+	}
+}
+
+// BenchmarkIsDeclared detects the format of the testdata corpus with the
+// IsDeclared functions, which only read the beginning of the documents
+func BenchmarkIsDeclared(b *testing.B) {
+	documents := corpus(b)
+	b.ResetTimer()
+
+	for n := 0; n < b.N; n++ {
+		for _, data := range documents {
+			_ = rss.IsDeclared(data) || atom.IsDeclared(data) ||
+				rdf.IsDeclared(data)
+		}
+	}
+}
+
+func TestParseErrors(t *testing.T) {
+	var documents = []struct {
+		data     string // input document
+		expected error  // expected error for errors.Is
+	}{
+		{"", ErrEmptyDocument},
+		{"\xef\xbb\xbf \n\t", ErrEmptyDocument},
+		{"<random></random>", ErrUnknownFormat},
+		{"text", ErrUnknownFormat},
+		{`<rdf:RDF xmlns:rdf="http://www.w3.org/1999/02/22-rdf-syntax-ns#">` +
+			`<channel></channel></rdf:RDF>`, ErrUnknownFormat},
+		{`{"version": "http://example.org/version/1"}`, ErrUnknownFormat},
+	}
+
+	for _, d := range documents {
+		f, err := Parse([]byte(d.data))
+		if f != nil || !errors.Is(err, d.expected) {
+			t.Errorf("[Clutch][Unit][Parse] '%q' : expected '%s', actual '%v'",
+				d.data, d.expected, err)
+		}
+	}
+}
+
+func TestParseUnsupportedVersion(t *testing.T) {
+	_, err := Parse([]byte(`<rss version=" 3.0 "><channel></channel></rss>`))
+
+	var versionErr *UnsupportedVersionError
+	if !errors.As(err, &versionErr) || versionErr.FeedType != FeedTypeRSS ||
+		versionErr.Version != "3.0" {
+		t.Errorf("[Clutch][Unit][Parse] expected an UnsupportedVersionError "+
+			"for RSS 3.0, actual '%v'", err)
+	}
+
+	if _, err = Parse([]byte(`<rss><channel></channel></rss>`)); err != nil {
+		t.Errorf("[Clutch][Unit][Parse] missing RSS version : %s", err)
+	}
+}
+
+func TestParseSyntaxError(t *testing.T) {
+	var documents = []struct {
+		data     string   // input document
+		feedType FeedType // expected type
+		line     int      // expected line
+		column   int      // expected column
+		offset   int64    // expected offset
+		cause    error    // expected underlying error
+	}{
+		{"<rss version=\"2.0\">\n<channel>\n<title>a</titl>", FeedTypeRSS, 3,
+			16, 45, &xml.SyntaxError{}},
+		{"<?xml version=\"1.0\"?>\n<feed xmlns=\"http://www.w3.org/2005/Atom\">" +
+			"\n<title>", FeedTypeAtom, 3, 8, 72, &xml.SyntaxError{}},
+		{"<a b=>", FeedTypeUnknown, 1, 7, 6, &xml.SyntaxError{}},
+		{"\xef\xbb\xbf{\"version\": \"https://jsonfeed.org/version/1.1\",\n" +
+			"  \"title\": x}", FeedTypeJSON, 2, 13, 63, &json.SyntaxError{}},
+		{"{\"version\": \"https://jsonfeed.org/version/1.1\",\n", FeedTypeJSON,
+			2, 1, 48, io.ErrUnexpectedEOF},
+	}
+
+	for _, d := range documents {
+		_, err := Parse([]byte(d.data))
+
+		var se *SyntaxError
+		if !errors.As(err, &se) {
+			t.Errorf("[Clutch][Unit][Parse] '%q' : expected a SyntaxError, "+
+				"actual '%v'", d.data, err)
+			continue
+		}
+
+		if se.FeedType != d.feedType || se.Line != d.line ||
+			se.Column != d.column || se.Offset != d.offset {
+			t.Errorf("[Clutch][Unit][Parse] '%q' : expected (%s, %d:%d, %d), "+
+				"actual (%s, %d:%d, %d)", d.data, d.feedType, d.line, d.column,
+				d.offset, se.FeedType, se.Line, se.Column, se.Offset)
+		}
+
+		switch cause := d.cause.(type) {
+		case *xml.SyntaxError:
+			if !errors.As(err, &cause) {
+				t.Errorf("[Clutch][Unit][Parse] '%q' : expected an "+
+					"xml.SyntaxError, actual '%v'", d.data, se.Err)
+			}
+		case *json.SyntaxError:
+			if !errors.As(err, &cause) {
+				t.Errorf("[Clutch][Unit][Parse] '%q' : expected a "+
+					"json.SyntaxError, actual '%v'", d.data, se.Err)
+			}
+		default:
+			if !errors.Is(err, cause) {
+				t.Errorf("[Clutch][Unit][Parse] '%q' : expected '%s', "+
+					"actual '%v'", d.data, cause, se.Err)
+			}
+		}
+	}
+}
+
+func TestParseReaderError(t *testing.T) {
+	errRead := errors.New("connection reset")
+	r := io.MultiReader(strings.NewReader(`<rss version="2.0"><channel>`),
+		iotest.ErrReader(errRead))
+
+	if _, err := ParseReader(r); err != errRead {
+		t.Errorf("[Clutch][Unit][ParseReader] expected '%s', actual '%v'",
+			errRead, err)
+	}
+}
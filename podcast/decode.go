@@ -0,0 +1,217 @@
+// This file is part of clutch.
+//
+// clutch is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// clutch is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with Foobar.  If not, see <http://www.gnu.org/licenses/>.
+
+package podcast
+
+import (
+	"encoding/xml"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/racam/clutch/internal/xmldec"
+)
+
+// inPodcast returns true for the namespaces of the Podcasting 2.0 elements
+var inPodcast = xmldec.InNamespace(Namespace, NamespaceGitHub)
+
+// Element returns the value into which the element, a child of the channel,
+// must be decoded, nil if it is not a podcast element of the channel. The
+// liveItem elements are items, they are decoded by the rss package.
+func (c *Channel) Element(name xml.Name) interface{} {
+	if !inPodcast(name.Space) {
+		return nil
+	}
+
+	switch name.Local {
+	case "funding":
+		c.Fundings = append(c.Fundings, Funding{})
+		return &c.Fundings[len(c.Fundings)-1]
+	case "guid":
+		return &c.GUID
+	case "locked":
+		return &c.Locked
+	case "person":
+		c.Persons = append(c.Persons, Person{})
+		return &c.Persons[len(c.Persons)-1]
+	case "value":
+		c.Values = append(c.Values, Value{})
+		return &c.Values[len(c.Values)-1]
+	}
+
+	return nil
+}
+
+// Element returns the value into which the element, a child of an item, must
+// be decoded, nil if it is not a podcast element of the item
+func (i *Item) Element(name xml.Name) interface{} {
+	if !inPodcast(name.Space) {
+		return nil
+	}
+
+	switch name.Local {
+	case "alternateEnclosure":
+		i.AlternateEnclosures = append(i.AlternateEnclosures,
+			AlternateEnclosure{})
+		return &i.AlternateEnclosures[len(i.AlternateEnclosures)-1]
+	case "chapters":
+		return &i.Chapters
+	case "person":
+		i.Persons = append(i.Persons, Person{})
+		return &i.Persons[len(i.Persons)-1]
+	case "soundbite":
+		i.Soundbites = append(i.Soundbites, Soundbite{})
+		return &i.Soundbites[len(i.Soundbites)-1]
+	case "transcript":
+		i.Transcripts = append(i.Transcripts, Transcript{})
+		return &i.Transcripts[len(i.Transcripts)-1]
+	case "value":
+		i.Values = append(i.Values, Value{})
+		return &i.Values[len(i.Values)-1]
+	}
+
+	return nil
+}
+
+// UnmarshalXML decodes the podcast:alternateEnclosure element. The feeds are
+// read leniently : a number which is not valid is decoded as 0.
+func (a *AlternateEnclosure) UnmarshalXML(d *xml.Decoder,
+	start xml.StartElement) error {
+	for _, attr := range start.Attr {
+		switch attr.Name.Local {
+		case "bitrate":
+			a.Bitrate, _ = strconv.ParseFloat(strings.TrimSpace(attr.Value), 64)
+		case "codecs":
+			a.Codecs = attr.Value
+		case "default":
+			a.Default = strings.TrimSpace(attr.Value) == "true"
+		case "height":
+			a.Height, _ = strconv.Atoi(strings.TrimSpace(attr.Value))
+		case "lang":
+			a.Lang = attr.Value
+		case "length":
+			a.Length, _ = strconv.ParseInt(strings.TrimSpace(attr.Value), 10, 64)
+		case "rel":
+			a.Rel = attr.Value
+		case "title":
+			a.Title = attr.Value
+		case "type":
+			a.Type = attr.Value
+		}
+	}
+
+	return xmldec.DecodeChildren(d, inPodcast, func(local string) interface{} {
+		switch local {
+		case "integrity":
+			return &a.Integrity
+		case "source":
+			a.Sources = append(a.Sources, Source{})
+			return &a.Sources[len(a.Sources)-1]
+		}
+		return nil
+	}, nil)
+}
+
+// UnmarshalXML decodes the podcast:locked element, only yes locks the podcast
+func (l *Locked) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
+	for _, a := range start.Attr {
+		if a.Name.Local == "owner" {
+			l.Owner = a.Value
+		}
+	}
+
+	var value string
+	err := d.DecodeElement(&value, &start)
+	l.Locked = strings.EqualFold(strings.TrimSpace(value), "yes")
+	return err
+}
+
+// UnmarshalXML decodes the podcast:soundbite element, a time which is not a
+// number of seconds is decoded as 0
+func (s *Soundbite) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
+	for _, a := range start.Attr {
+		switch a.Name.Local {
+		case "duration":
+			s.Duration = parseSeconds(a.Value)
+		case "startTime":
+			s.StartTime = parseSeconds(a.Value)
+		}
+	}
+
+	var value string
+	err := d.DecodeElement(&value, &start)
+	s.Title = strings.TrimSpace(value)
+	return err
+}
+
+// UnmarshalXML decodes the podcast:value element, see xmldec.DecodeChildren
+func (v *Value) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
+	for _, a := range start.Attr {
+		switch a.Name.Local {
+		case "method":
+			v.Method = a.Value
+		case "suggested":
+			v.Suggested = a.Value
+		case "type":
+			v.Type = a.Value
+		}
+	}
+
+	return xmldec.DecodeChildren(d, inPodcast, func(local string) interface{} {
+		if local == "valueRecipient" {
+			v.Recipients = append(v.Recipients, ValueRecipient{})
+			return &v.Recipients[len(v.Recipients)-1]
+		}
+		return nil
+	}, nil)
+}
+
+// UnmarshalXML decodes the podcast:valueRecipient element, a split which is
+// not an integer is decoded as 0
+func (r *ValueRecipient) UnmarshalXML(d *xml.Decoder,
+	start xml.StartElement) error {
+	for _, a := range start.Attr {
+		switch a.Name.Local {
+		case "address":
+			r.Address = a.Value
+		case "customKey":
+			r.CustomKey = a.Value
+		case "customValue":
+			r.CustomValue = a.Value
+		case "fee":
+			r.Fee = strings.TrimSpace(a.Value) == "true"
+		case "name":
+			r.Name = a.Value
+		case "split":
+			r.Split, _ = strconv.Atoi(strings.TrimSpace(a.Value))
+		case "type":
+			r.Type = a.Value
+		}
+	}
+
+	return d.Skip()
+}
+
+// parseSeconds returns the duration of a number of seconds, which may have a
+// fractional part, 0 if the value is not valid
+func parseSeconds(value string) time.Duration {
+	f, err := strconv.ParseFloat(strings.TrimSpace(value), 64)
+	if err != nil || !(f >= 0) || math.IsInf(f, 0) {
+		return 0
+	}
+
+	return time.Duration(f * float64(time.Second))
+}
@@ -0,0 +1,181 @@
+// This file is part of clutch.
+//
+// clutch is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// clutch is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with Foobar.  If not, see <http://www.gnu.org/licenses/>.
+
+package podcast
+
+import (
+	"encoding/xml"
+	"strconv"
+	"time"
+
+	"github.com/racam/clutch/internal/xmlenc"
+)
+
+// Write writes the podcast elements of the channel, the empty ones are
+// omitted. The liveItem elements are written by the rss package.
+func (c *Channel) Write(enc *xmlenc.Encoder) {
+	writeText(enc, "guid", c.GUID)
+
+	if c.Locked != (Locked{}) {
+		locked := "no"
+		if c.Locked.Locked {
+			locked = "yes"
+		}
+		writeText(enc, "locked", locked, xmlenc.Attr("owner", c.Locked.Owner))
+	}
+
+	for _, f := range c.Fundings {
+		writeText(enc, "funding", f.Value, xmlenc.Attr("url", f.URL))
+	}
+
+	writePersons(enc, c.Persons)
+	writeValues(enc, c.Values)
+}
+
+// Write writes the podcast elements of the item, the empty ones are omitted
+func (i *Item) Write(enc *xmlenc.Encoder) {
+	for _, t := range i.Transcripts {
+		writeEmpty(enc, "transcript", xmlenc.Attr("url", t.URL),
+			xmlenc.Attr("type", t.Type), xmlenc.Attr("language", t.Language),
+			xmlenc.Attr("rel", t.Rel))
+	}
+
+	if i.Chapters != (Chapters{}) {
+		writeEmpty(enc, "chapters", xmlenc.Attr("url", i.Chapters.URL),
+			xmlenc.Attr("type", i.Chapters.Type))
+	}
+
+	for _, s := range i.Soundbites {
+		n := name("soundbite")
+		enc.Start(n, xmlenc.Attr("startTime", formatSeconds(s.StartTime)),
+			xmlenc.Attr("duration", formatSeconds(s.Duration)))
+		enc.Text(s.Title)
+		enc.End(n)
+	}
+
+	writePersons(enc, i.Persons)
+
+	for _, a := range i.AlternateEnclosures {
+		isDefault := ""
+		if a.Default {
+			isDefault = "true"
+		}
+
+		n := name("alternateEnclosure")
+		enc.Start(n,
+			xmlenc.Attr("type", a.Type),
+			xmlenc.Attr("length", itoa(a.Length)),
+			xmlenc.Attr("bitrate", ftoa(a.Bitrate)),
+			xmlenc.Attr("height", itoa(int64(a.Height))),
+			xmlenc.Attr("lang", a.Lang),
+			xmlenc.Attr("title", a.Title),
+			xmlenc.Attr("rel", a.Rel),
+			xmlenc.Attr("codecs", a.Codecs),
+			xmlenc.Attr("default", isDefault))
+		for _, s := range a.Sources {
+			writeEmpty(enc, "source", xmlenc.Attr("uri", s.URI),
+				xmlenc.Attr("contentType", s.ContentType))
+		}
+		if a.Integrity != (Integrity{}) {
+			writeEmpty(enc, "integrity", xmlenc.Attr("type", a.Integrity.Type),
+				xmlenc.Attr("value", a.Integrity.Value))
+		}
+		enc.End(n)
+	}
+
+	writeValues(enc, i.Values)
+}
+
+func writePersons(enc *xmlenc.Encoder, persons []Person) {
+	for _, p := range persons {
+		writeText(enc, "person", p.Name, xmlenc.Attr("role", p.Role),
+			xmlenc.Attr("group", p.Group), xmlenc.Attr("img", p.Img),
+			xmlenc.Attr("href", p.Href))
+	}
+}
+
+func writeValues(enc *xmlenc.Encoder, values []Value) {
+	for _, v := range values {
+		n := name("value")
+		enc.Start(n, xmlenc.Attr("type", v.Type),
+			xmlenc.Attr("method", v.Method),
+			xmlenc.Attr("suggested", v.Suggested))
+
+		for _, r := range v.Recipients {
+			fee := ""
+			if r.Fee {
+				fee = "true"
+			}
+
+			writeEmpty(enc, "valueRecipient",
+				xmlenc.Attr("name", r.Name),
+				xmlenc.Attr("customKey", r.CustomKey),
+				xmlenc.Attr("customValue", r.CustomValue),
+				xmlenc.Attr("type", r.Type),
+				xmlenc.Attr("address", r.Address),
+				xmlenc.Attr("split", strconv.Itoa(r.Split)),
+				xmlenc.Attr("fee", fee))
+		}
+
+		enc.End(n)
+	}
+}
+
+// writeText writes an element which contains character data, it is omitted
+// if the value is empty
+func writeText(enc *xmlenc.Encoder, local string, value string,
+	attrs ...xml.Attr) {
+	if value == "" {
+		return
+	}
+
+	n := name(local)
+	enc.Start(n, attrs...)
+	enc.Text(value)
+	enc.End(n)
+}
+
+// writeEmpty writes an element which only has attributes
+func writeEmpty(enc *xmlenc.Encoder, local string, attrs ...xml.Attr) {
+	n := name(local)
+	enc.Start(n, attrs...)
+	enc.End(n)
+}
+
+func name(local string) xml.Name {
+	return xml.Name{Space: Namespace, Local: local}
+}
+
+// formatSeconds returns the number of seconds of the duration, the required
+// times of a soundbite are written even when they are 0
+func formatSeconds(d time.Duration) string {
+	return strconv.FormatFloat(d.Seconds(), 'f', -1, 64)
+}
+
+// itoa returns the decimal representation of i, the empty string for 0
+func itoa(i int64) string {
+	if i == 0 {
+		return ""
+	}
+	return strconv.FormatInt(i, 10)
+}
+
+// ftoa returns the decimal representation of f, the empty string for 0
+func ftoa(f float64) string {
+	if f == 0 {
+		return ""
+	}
+	return strconv.FormatFloat(f, 'f', -1, 64)
+}
@@ -0,0 +1,66 @@
+// This file is part of clutch.
+//
+// clutch is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// clutch is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with Foobar.  If not, see <http://www.gnu.org/licenses/>.
+
+package podcast
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/racam/clutch/internal/xmlenc"
+)
+
+func TestWrite(t *testing.T) {
+	c := Channel{Locked: Locked{Owner: "john@example.org"}}
+	i := Item{
+		Chapters:    Chapters{URL: "https://example.org/1.json"},
+		Soundbites:  []Soundbite{{Duration: 90 * time.Second}},
+		Transcripts: []Transcript{{Type: "text/vtt", URL: "https://example.org/1.vtt"}},
+		Values: []Value{{Recipients: []ValueRecipient{{Address: "02d5c1bf",
+			Split: 100}}, Type: "lightning"}},
+	}
+
+	var buf bytes.Buffer
+	enc := xmlenc.NewEncoder(&buf)
+	c.Write(enc)
+	i.Write(enc)
+	if err := enc.Close(); err != nil {
+		t.Fatalf("[Podcast][Marshal] %s", err)
+	}
+
+	var expected = []string{
+		`<locked xmlns="https://podcastindex.org/namespace/1.0" owner="john@example.org">no</locked>`,
+		`<transcript xmlns="https://podcastindex.org/namespace/1.0" url="https://example.org/1.vtt" type="text/vtt"></transcript>`,
+		`<chapters xmlns="https://podcastindex.org/namespace/1.0" url="https://example.org/1.json"></chapters>`,
+		`startTime="0" duration="90"`,
+		`address="02d5c1bf" split="100"`,
+	}
+
+	for _, e := range expected {
+		if !strings.Contains(buf.String(), e) {
+			t.Errorf("[Podcast][Marshal] expected '%s' in\n%s", e,
+				buf.String())
+		}
+	}
+
+	for _, e := range []string{"<guid", "<funding", "<person", "fee="} {
+		if strings.Contains(buf.String(), e) {
+			t.Errorf("[Podcast][Marshal] unexpected '%s' in\n%s", e,
+				buf.String())
+		}
+	}
+}
@@ -0,0 +1,149 @@
+// This file is part of clutch.
+//
+// clutch is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// clutch is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with Foobar.  If not, see <http://www.gnu.org/licenses/>.
+
+// Package podcast please Refer to https://podcastindex.org/namespace/1.0
+package podcast
+
+import "time"
+
+// Namespace is the namespace of the Podcasting 2.0 elements
+// source : https://podcastindex.org/namespace/1.0
+const Namespace string = "https://podcastindex.org/namespace/1.0"
+
+// NamespaceGitHub is the first namespace of the Podcasting 2.0 elements,
+// still found in the feeds
+const NamespaceGitHub string = "https://github.com/Podcastindex-org/podcast-namespace/blob/main/docs/1.0.md"
+
+// Channel is a Podcasting 2.0 structure like describe in
+// https://podcastindex.org/namespace/1.0
+// It holds the podcast elements of the channel.
+type Channel struct {
+	Fundings []Funding
+	GUID     string
+	Locked   Locked
+	Persons  []Person
+	Values   []Value
+}
+
+// Item is a Podcasting 2.0 structure like describe in
+// https://podcastindex.org/namespace/1.0
+// It holds the podcast elements of an item or of a live item.
+type Item struct {
+	AlternateEnclosures []AlternateEnclosure
+	Chapters            Chapters
+	Persons             []Person
+	Soundbites          []Soundbite
+	Transcripts         []Transcript
+	Values              []Value
+}
+
+// AlternateEnclosure is a Podcasting 2.0 structure like describe in
+// https://podcastindex.org/namespace/1.0#alternate-enclosure
+type AlternateEnclosure struct {
+	Bitrate   float64 // bits per second
+	Codecs    string
+	Default   bool
+	Height    int
+	Integrity Integrity
+	Lang      string
+	Length    int64 // bytes
+	Rel       string
+	Sources   []Source
+	Title     string
+	Type      string
+}
+
+// Integrity is the hash or the signature of an alternate enclosure
+// source : https://podcastindex.org/namespace/1.0#integrity
+type Integrity struct {
+	Type  string `xml:"type,attr"` // sri or pgp-signature
+	Value string `xml:"value,attr"`
+}
+
+// Source is an URI of an alternate enclosure
+// source : https://podcastindex.org/namespace/1.0#source
+type Source struct {
+	ContentType string `xml:"contentType,attr"`
+	URI         string `xml:"uri,attr"`
+}
+
+// Chapters is a Podcasting 2.0 structure like describe in
+// https://podcastindex.org/namespace/1.0#chapters
+type Chapters struct {
+	Type string `xml:"type,attr"`
+	URL  string `xml:"url,attr"`
+}
+
+// Funding is a Podcasting 2.0 structure like describe in
+// https://podcastindex.org/namespace/1.0#funding
+type Funding struct {
+	URL   string `xml:"url,attr"`
+	Value string `xml:",chardata"`
+}
+
+// Locked is a Podcasting 2.0 structure like describe in
+// https://podcastindex.org/namespace/1.0#locked
+type Locked struct {
+	Locked bool // yes forbids the import of the podcast by other platforms
+	Owner  string
+}
+
+// Person is a Podcasting 2.0 structure like describe in
+// https://podcastindex.org/namespace/1.0#person
+type Person struct {
+	Group string `xml:"group,attr"`
+	Href  string `xml:"href,attr"`
+	Img   string `xml:"img,attr"`
+	Name  string `xml:",chardata"`
+	Role  string `xml:"role,attr"`
+}
+
+// Soundbite is a Podcasting 2.0 structure like describe in
+// https://podcastindex.org/namespace/1.0#soundbite
+type Soundbite struct {
+	Duration  time.Duration
+	StartTime time.Duration
+	Title     string
+}
+
+// Transcript is a Podcasting 2.0 structure like describe in
+// https://podcastindex.org/namespace/1.0#transcript
+type Transcript struct {
+	Language string `xml:"language,attr"`
+	Rel      string `xml:"rel,attr"` // captions when the transcript is timed
+	Type     string `xml:"type,attr"`
+	URL      string `xml:"url,attr"`
+}
+
+// Value is a Podcasting 2.0 structure like describe in
+// https://podcastindex.org/namespace/1.0#value
+type Value struct {
+	Method     string
+	Recipients []ValueRecipient
+	Suggested  string
+	Type       string
+}
+
+// ValueRecipient is a Podcasting 2.0 structure like describe in
+// https://podcastindex.org/namespace/1.0#value-recipient
+type ValueRecipient struct {
+	Address     string
+	CustomKey   string
+	CustomValue string
+	Fee         bool
+	Name        string
+	Split       int
+	Type        string
+}
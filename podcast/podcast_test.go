@@ -0,0 +1,122 @@
+// This file is part of clutch.
+//
+// clutch is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// clutch is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with Foobar.  If not, see <http://www.gnu.org/licenses/>.
+
+package podcast
+
+import (
+	"encoding/xml"
+	"io/ioutil"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/racam/clutch/internal/xmltest"
+)
+
+var prefix = "../testdata/podcast/"
+
+func TestElement(t *testing.T) {
+	channel := Channel{}
+	item := Item{}
+
+	var files = []struct {
+		filename string
+		element  func(name xml.Name) interface{}
+		skipped  int
+	}{
+		{"unit_01_channel.xml", channel.Element, 2},
+		{"unit_02_item.xml", item.Element, 1},
+	}
+
+	for _, file := range files {
+		data, err := ioutil.ReadFile(prefix + file.filename)
+		if err != nil {
+			t.Fatalf("[Podcast][Unit][Element] file '%s' : is missing",
+				prefix+file.filename)
+		}
+
+		skipped, err := xmltest.Decode(data, file.element)
+		if err != nil {
+			t.Fatalf("[Podcast][Unit][Element] file '%s' : %s", file.filename,
+				err)
+		}
+
+		if skipped != file.skipped {
+			t.Errorf("[Podcast][Unit][Element] file '%s' : expected %d "+
+				"skipped elements, actual %d", file.filename, file.skipped,
+				skipped)
+		}
+	}
+
+	expectedChannel := Channel{
+		Fundings: []Funding{{URL: "https://example.org/donate",
+			Value: "Support the show!"}},
+		GUID:   "917393e3-1b1e-5cef-ace4-edaa54e1f810",
+		Locked: Locked{Locked: true, Owner: "john@example.org"},
+		Persons: []Person{{Img: "http://example.org/john.jpg",
+			Name: "John Doe", Role: "host"}},
+		Values: []Value{{
+			Method: "keysend",
+			Recipients: []ValueRecipient{
+				{Address: "02d5c1bf", Name: "John Doe", Split: 90,
+					Type: "node"},
+				{Address: "03ae9f91", Fee: true, Name: "Host", Type: "node"},
+			},
+			Suggested: "0.00000005000",
+			Type:      "lightning",
+		}},
+	}
+
+	if !reflect.DeepEqual(channel, expectedChannel) {
+		t.Errorf("[Podcast][Unit][Element] expected %+v, actual %+v",
+			expectedChannel, channel)
+	}
+
+	expectedItem := Item{
+		AlternateEnclosures: []AlternateEnclosure{{
+			Bitrate: 96000,
+			Default: true,
+			Integrity: Integrity{Type: "sri", Value: "sha384-ExVqijgYHm15PqQqdX" +
+				"fW95x+Rs6C+d6E/ICxyQOeFevnxNLR/wtJNrNYTjIysUBo"},
+			Length: 32400000,
+			Sources: []Source{
+				{URI: "https://example.org/1.opus"},
+				{ContentType: "audio/opus", URI: "ipfs://QmdwGqd3d2gFPGeJNLLC" +
+					"shdiPert45fMu84552Y4XHTy4y"},
+			},
+			Title: "High quality",
+			Type:  "audio/opus",
+		}},
+		Chapters: Chapters{Type: "application/json+chapters",
+			URL: "https://example.org/1.json"},
+		Persons: []Person{{Href: "https://example.org/jane",
+			Name: "Jane Doe", Role: "guest"}},
+		Soundbites: []Soundbite{
+			{Duration: time.Minute, StartTime: 73500 * time.Millisecond,
+				Title: "Why the Podcast Namespace Matters"},
+			{},
+		},
+		Transcripts: []Transcript{
+			{Language: "en", Rel: "captions", Type: "text/vtt",
+				URL: "https://example.org/1.vtt"},
+			{Type: "text/html", URL: "https://example.org/1.html"},
+		},
+	}
+
+	if !reflect.DeepEqual(item, expectedItem) {
+		t.Errorf("[Podcast][Unit][Element] expected %+v, actual %+v",
+			expectedItem, item)
+	}
+}
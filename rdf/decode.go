@@ -0,0 +1,115 @@
+// This file is part of clutch.
+//
+// clutch is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// clutch is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with Foobar.  If not, see <http://www.gnu.org/licenses/>.
+
+package rdf
+
+import (
+	"encoding/xml"
+
+	"github.com/racam/clutch/content"
+	"github.com/racam/clutch/dc"
+	"github.com/racam/clutch/ext"
+	"github.com/racam/clutch/internal/xmldec"
+)
+
+// about returns the rdf:about attribute of the element
+func about(start xml.StartElement) string {
+	for _, a := range start.Attr {
+		if a.Name.Local == "about" {
+			return a.Value
+		}
+	}
+
+	return ""
+}
+
+// UnmarshalXML decodes the channel element, see xmldec.DecodeChildren
+func (c *Channel) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
+	c.About = about(start)
+	c.XMLName = start.Name
+
+	inRSS := xmldec.InNamespace(start.Name.Space)
+	return xmldec.DecodeChildren(d, inRSS, func(name string) interface{} {
+		switch name {
+		case "description":
+			return &c.Description
+		case "image":
+			return &c.Image
+		case "items":
+			return &c.Items
+		case "link":
+			return &c.Link
+		case "textinput":
+			return &c.TextInput
+		case "title":
+			return &c.Title
+		}
+		return nil
+	}, c.module)
+}
+
+// module returns the value of an element of the modules of the channel, see
+// ext.Modules
+func (c *Channel) module(name xml.Name) interface{} {
+	return c.Modules.Element(name, c.fields)
+}
+
+// fields returns the module of the namespace kept in a field of the channel,
+// see ext.Modules
+func (c *Channel) fields(space string) ext.Module {
+	switch space {
+	case dc.Namespace, dc.NamespaceTerms:
+		return &c.DublinCore
+	}
+
+	return nil
+}
+
+// UnmarshalXML decodes the item element, see xmldec.DecodeChildren
+func (i *Item) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
+	i.About = about(start)
+
+	inRSS := xmldec.InNamespace(start.Name.Space)
+	return xmldec.DecodeChildren(d, inRSS, func(name string) interface{} {
+		switch name {
+		case "description":
+			return &i.Description
+		case "link":
+			return &i.Link
+		case "title":
+			return &i.Title
+		}
+		return nil
+	}, i.module)
+}
+
+// module returns the value of an element of the modules of the item, see
+// ext.Modules
+func (i *Item) module(name xml.Name) interface{} {
+	return i.Modules.Element(name, i.fields)
+}
+
+// fields returns the module of the namespace kept in a field of the item, see
+// ext.Modules
+func (i *Item) fields(space string) ext.Module {
+	switch space {
+	case content.Namespace:
+		return (*content.Encoded)(&i.Content)
+	case dc.Namespace, dc.NamespaceTerms:
+		return &i.DublinCore
+	}
+
+	return nil
+}
@@ -0,0 +1,131 @@
+// This file is part of clutch.
+//
+// clutch is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// clutch is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with Foobar.  If not, see <http://www.gnu.org/licenses/>.
+
+package rdf
+
+import (
+	"bytes"
+	"encoding/xml"
+	"errors"
+	"io"
+
+	"github.com/racam/clutch/content"
+	"github.com/racam/clutch/internal/xmldec"
+)
+
+// NamespaceRDF is the namespace of the rdf:RDF root element
+const NamespaceRDF string = "http://www.w3.org/1999/02/22-rdf-syntax-ns#"
+
+// Namespaces of the channel element, one for each version of the RDF Site
+// Summary
+const (
+	NamespaceRSS090 string = "http://my.netscape.com/rdf/simple/0.9/"
+	NamespaceRSS10  string = "http://purl.org/rss/1.0/"
+)
+
+// NamespaceContent is the namespace of the content module, its encoded element
+// holds the full content of an item as escaped HTML
+// source : https://web.resource.org/rss/1.0/modules/content/
+const NamespaceContent string = content.Namespace
+
+// IsDeclared tries to find a rdf:RDF element at the root of the xml document
+// and a channel element in the RSS 1.0 (or RSS 0.90) namespace. Only the
+// elements before the channel are read, the rest of the document is not
+// checked.
+// source : http://web.resource.org/rss/1.0/spec#s5.2
+func IsDeclared(data []byte) bool {
+	d := xmldec.NewDecoder(bytes.NewReader(data))
+
+	root, err := xmldec.RootElement(d)
+	if err != nil || root.Name != (xml.Name{Space: NamespaceRDF, Local: "RDF"}) {
+		return false
+	}
+
+	return DeclaredVersion(d) != ""
+}
+
+// DeclaredVersion reads the children of the rdf:RDF element, whose start has
+// just been read from the decoder, until the channel element and returns the
+// version matching its namespace : "1.0" or "0.90". An empty string is
+// returned if there is no channel in these namespaces.
+func DeclaredVersion(d *xml.Decoder) string {
+	for {
+		child, err := xmldec.NextChild(d)
+		if err != nil {
+			return ""
+		}
+
+		if child.Name.Local == "channel" {
+			return version(child.Name.Space)
+		}
+
+		if err := d.Skip(); err != nil {
+			return ""
+		}
+	}
+}
+
+// Parse parses the RDF-encoded data into an RDF struct and return it, see
+// ParseReader
+func Parse(data []byte) (*RDF, error) {
+	return ParseReader(bytes.NewReader(data))
+}
+
+// ParseReader parses the RDF document read from r into an RDF struct and
+// return it. The document is decoded in a single pass, without being buffered.
+func ParseReader(r io.Reader) (*RDF, error) {
+	d := xmldec.NewDecoder(r)
+
+	start, err := xmldec.RootElement(d)
+	if err != nil {
+		return nil, err
+	}
+
+	return ParseElement(d, start)
+}
+
+// ParseElement parses the RDF document of the decoder, whose root element
+// start has just been read. It lets a caller which has read the root element
+// to detect the format continue the decoding.
+func ParseElement(d *xml.Decoder, start xml.StartElement) (*RDF, error) {
+	r := RDF{}
+
+	err := d.DecodeElement(&r, &start)
+	if err != nil {
+		return nil, err
+	}
+
+	// rdf:RDF is a generic container, the namespace of the channel is what
+	// tells us that the document is a feed
+	r.Version = version(r.Channel.XMLName.Space)
+	if r.Version == "" {
+		return nil, errors.New("rdf:RDF does not contain an RSS 1.0 channel")
+	}
+
+	return &r, nil
+}
+
+// version returns the version of RSS 1.0 matching the namespace of the
+// channel element
+func version(namespace string) string {
+	switch namespace {
+	case NamespaceRSS10:
+		return "1.0"
+	case NamespaceRSS090:
+		return "0.90"
+	}
+
+	return ""
+}
@@ -0,0 +1,135 @@
+// This file is part of clutch.
+//
+// clutch is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// clutch is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with Foobar.  If not, see <http://www.gnu.org/licenses/>.
+
+package rdf
+
+import (
+	"io/ioutil"
+	"testing"
+)
+
+var prefix = "../testdata/rdf/"
+
+func TestIsDeclared(t *testing.T) {
+	var namespaces = []struct {
+		filename string // input file
+		expected bool   // expected result
+	}{
+		{"unit_01_IsDeclared.xml", true},
+		{"unit_02_IsDeclared.xml", true},
+		{"unit_03_IsDeclared.xml", false},
+		{"unit_04_IsDeclared.xml", false},
+	}
+
+	for _, ns := range namespaces {
+
+		f, err := ioutil.ReadFile(prefix + ns.filename)
+
+		if err != nil {
+			t.Errorf("[RDF][Unit][IsDeclared] file '%s' : is missing",
+				prefix+ns.filename)
+		} else {
+			res := IsDeclared(f)
+
+			//Test if isDeclared return an expected result
+			if res != ns.expected {
+				t.Errorf(`[RDF][Unit] file '%s' : expected result '%t',
+					actual %t`, ns.filename, ns.expected, res)
+			}
+		}
+	}
+}
+
+func TestParse(t *testing.T) {
+	filename := "unit_05_parse.xml"
+	f, err := ioutil.ReadFile(prefix + filename)
+
+	if err != nil {
+		t.Fatalf("[RDF][Unit][Parse] file '%s' : is missing", prefix+filename)
+	}
+
+	r, err := Parse(f)
+	if err != nil {
+		t.Fatalf("[RDF][Unit][Parse] file '%s' : %s", filename, err)
+	}
+
+	if r.Version != "1.0" {
+		t.Errorf("[RDF][Unit][Parse] Version : expected '1.0', actual '%s'",
+			r.Version)
+	}
+
+	if r.Channel.Title != "Channel title" {
+		t.Errorf("[RDF][Unit][Parse] Channel.Title : expected 'Channel title', "+
+			"actual '%s'", r.Channel.Title)
+	}
+
+	if len(r.Channel.Items.Resource) != 2 {
+		t.Errorf("[RDF][Unit][Parse] Channel.Items : expected 2 resources, "+
+			"actual %d", len(r.Channel.Items.Resource))
+	} else if r.Channel.Items.Resource[1].Resource != "http://example.org/2" {
+		t.Errorf("[RDF][Unit][Parse] Channel.Items : expected "+
+			"'http://example.org/2', actual '%s'",
+			r.Channel.Items.Resource[1].Resource)
+	}
+
+	if r.Image.URL != "http://example.org/logo.png" {
+		t.Errorf("[RDF][Unit][Parse] Image.URL : expected "+
+			"'http://example.org/logo.png', actual '%s'", r.Image.URL)
+	}
+
+	if len(r.Item) != 2 {
+		t.Fatalf("[RDF][Unit][Parse] Item : expected 2 items, actual %d",
+			len(r.Item))
+	}
+
+	if r.Item[0].About != "http://example.org/1" {
+		t.Errorf("[RDF][Unit][Parse] Item.About : expected "+
+			"'http://example.org/1', actual '%s'", r.Item[0].About)
+	}
+
+	if r.Item[0].Content != "<p>Item 1 content</p>" {
+		t.Errorf("[RDF][Unit][Parse] Item.Content : expected "+
+			"'<p>Item 1 content</p>', actual '%s'", r.Item[0].Content)
+	}
+
+	if len(r.Channel.DublinCore.Creator) != 1 ||
+		len(r.Item[0].DublinCore.Date) != 1 {
+		t.Errorf("[RDF][Unit][Parse] DublinCore : unexpected %+v and %+v",
+			r.Channel.DublinCore, r.Item[0].DublinCore)
+	}
+
+	if r.TextInput.Name != "q" {
+		t.Errorf("[RDF][Unit][Parse] TextInput.Name : expected 'q', actual '%s'",
+			r.TextInput.Name)
+	}
+}
+
+func TestFailParse(t *testing.T) {
+	filename := "unit_03_IsDeclared.xml"
+	f, err := ioutil.ReadFile(prefix + filename)
+
+	if err != nil {
+		t.Fatalf("[RDF][Unit][Parse] file '%s' : is missing", prefix+filename)
+	}
+
+	res, err := Parse(f)
+	if res != nil {
+		t.Errorf("[RDF][Unit][Parse] file '%s' : RDF is not nil", filename)
+	}
+
+	if err == nil {
+		t.Errorf("[RDF][Unit][Parse] file '%s' : error is nil", filename)
+	}
+}
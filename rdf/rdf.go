@@ -0,0 +1,93 @@
+// This file is part of clutch.
+//
+// clutch is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// clutch is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with Foobar.  If not, see <http://www.gnu.org/licenses/>.
+
+// Package rdf please Refer to http://web.resource.org/rss/1.0/spec
+package rdf
+
+import (
+	"encoding/xml"
+
+	"github.com/racam/clutch/dc"
+	"github.com/racam/clutch/ext"
+)
+
+// RDF is a RSS 1.0 structure like describe in
+// http://web.resource.org/rss/1.0/spec#s5.2
+type RDF struct {
+	Channel   Channel   `xml:"channel"`
+	Image     Image     `xml:"image"`
+	Item      []Item    `xml:"item"`
+	TextInput TextInput `xml:"textinput"`
+	Version   string    `xml:"-"` //Fill with the namespace of the channel
+	XMLName   xml.Name  `xml:"http://www.w3.org/1999/02/22-rdf-syntax-ns# RDF"`
+}
+
+// Channel is a RSS 1.0 structure like describe in
+// http://web.resource.org/rss/1.0/spec#s5.3
+type Channel struct {
+	About       string        `xml:"about,attr"`
+	Description string        `xml:"description"`
+	DublinCore  dc.DublinCore `xml:"-"` //Fill with the dc elements
+	Image       Resource      `xml:"image"`
+	Items       Items         `xml:"items"`
+	Link        string        `xml:"link"`
+	Modules     ext.Modules   `xml:"-"` //Fill with the registered modules
+	TextInput   Resource      `xml:"textinput"`
+	Title       string        `xml:"title"`
+	XMLName     xml.Name      `xml:"channel"`
+}
+
+// Items is a RSS 1.0 structure like describe in
+// http://web.resource.org/rss/1.0/spec#s5.3.5
+type Items struct {
+	Resource []Resource `xml:"Seq>li"`
+}
+
+// Image is a RSS 1.0 structure like describe in
+// http://web.resource.org/rss/1.0/spec#s5.4
+type Image struct {
+	About string `xml:"about,attr"`
+	Link  string `xml:"link"`
+	Title string `xml:"title"`
+	URL   string `xml:"url"`
+}
+
+// Item is a RSS 1.0 structure like describe in
+// http://web.resource.org/rss/1.0/spec#s5.5
+type Item struct {
+	About       string        `xml:"about,attr"`
+	Content     string        `xml:"http://purl.org/rss/1.0/modules/content/ encoded"`
+	Description string        `xml:"description"`
+	DublinCore  dc.DublinCore `xml:"-"` //Fill with the dc elements
+	Link        string        `xml:"link"`
+	Modules     ext.Modules   `xml:"-"` //Fill with the registered modules
+	Title       string        `xml:"title"`
+}
+
+// TextInput is a RSS 1.0 structure like describe in
+// http://web.resource.org/rss/1.0/spec#s5.6
+type TextInput struct {
+	About       string `xml:"about,attr"`
+	Description string `xml:"description"`
+	Link        string `xml:"link"`
+	Name        string `xml:"name"`
+	Title       string `xml:"title"`
+}
+
+// Resource is a reference to another element of the document through its
+// rdf:resource attribute
+type Resource struct {
+	Resource string `xml:"resource,attr"`
+}
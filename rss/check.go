@@ -0,0 +1,521 @@
+// This file is part of clutch.
+//
+// clutch is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// clutch is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with Foobar.  If not, see <http://www.gnu.org/licenses/>.
+
+package rss
+
+import (
+	"bytes"
+	"net/url"
+	"reflect"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/racam/clutch/date"
+	"github.com/racam/clutch/internal/xmldec"
+	"github.com/racam/clutch/itunes"
+	"github.com/racam/clutch/validation"
+)
+
+// profile is the name of the best practices of the RSS Advisory Board
+// source : https://www.rssboard.org/rss-profile
+const profile = "RSS Best Practices Profile"
+
+// applePodcasts is the name of the requirements of Apple Podcasts for the
+// feeds of the podcasts, they have no section
+// source : https://help.apple.com/itc/podcasts_connect/#/itcb54353390
+const applePodcasts = "Apple Podcasts"
+
+// email matches an email address optionally followed by the name of the
+// person between parentheses, e.g. "john@example.org (John Doe)"
+var email = regexp.MustCompile(`^[^@\s()<>]+@[^@\s()<>]+\.[^@\s()<>]+` +
+	`( \(.+\))?$`)
+
+// artwork matches the path of the image of a podcast, a JPEG or a PNG
+var artwork = regexp.MustCompile(`(?i)\.(jpe?g|png)$`)
+
+// ValidationReport lists the violations of the RSS specification found in a
+// feed, see validation.Report
+type ValidationReport = validation.Report
+
+// Check verifies the requirements of the RSS specification of the version of
+// the feed and returns the first violation, see Validate
+func Check(r *RSS) error {
+	return Validate(r).Err()
+}
+
+// Validate verifies the requirements of the RSS specification of the version
+// of the feed, unknown versions are checked with the RSS 2.0 rules, and
+// returns every violation. The recommendations of the RSS Advisory Board are
+// warnings, except when the specification is ambiguous and the profile
+// settles it with a MUST.
+// The feed is checked as it is in memory : the positions are unknown,
+// ValidateDocument checks the source document.
+func Validate(r *RSS) *ValidationReport {
+	v := validator{report: &ValidationReport{}, spec: r.Spec()}
+	r.Channel.check(&v, "/rss/channel")
+	return v.report
+}
+
+// ValidateDocument parses the RSS-encoded data and validates it like
+// Validate, the violations carry the line and the column of the elements. An
+// error is returned if the data can not be parsed.
+func ValidateDocument(data []byte) (*ValidationReport, error) {
+	r, err := Parse(data)
+	if err != nil {
+		return nil, err
+	}
+
+	positions, err := xmldec.Record(bytes.NewReader(data))
+	if err != nil {
+		return nil, err
+	}
+
+	v := validator{positions: positions, report: &ValidationReport{},
+		spec: r.Spec()}
+	r.Channel.check(&v, "/rss/channel")
+	return v.report, nil
+}
+
+// validator fills the report, the positions are nil when the source is
+// unknown
+type validator struct {
+	positions *xmldec.Positions
+	report    *ValidationReport
+	spec      Spec
+}
+
+// error adds the violation of a rule of the specification, the section is
+// the anchor of the rule in the specification
+func (v *validator) error(path string, section string, message string) {
+	v.add(path, section, "RSS "+v.spec.Version, validation.SeverityError,
+		message)
+}
+
+// profileError adds the violation of a MUST rule of the best practices
+func (v *validator) profileError(path string, section string,
+	message string) {
+	v.add(path, section, profile, validation.SeverityError, message)
+}
+
+// warning adds the violation of a SHOULD rule of the best practices
+func (v *validator) warning(path string, section string, message string) {
+	v.add(path, section, profile, validation.SeverityWarning, message)
+}
+
+// podcastError adds the violation of a requirement of Apple Podcasts
+func (v *validator) podcastError(path string, message string) {
+	v.add(path, "", applePodcasts, validation.SeverityError, message)
+}
+
+// podcastWarning adds the violation of a recommendation of Apple Podcasts
+func (v *validator) podcastWarning(path string, message string) {
+	v.add(path, "", applePodcasts, validation.SeverityWarning, message)
+}
+
+func (v *validator) add(path string, section string, spec string,
+	severity validation.Severity, message string) {
+	line, column := v.positions.Position(path)
+
+	v.report.Add(validation.Violation{
+		Column:   column,
+		Line:     line,
+		Message:  message,
+		Path:     path,
+		Section:  section,
+		Severity: severity,
+		Spec:     spec,
+	})
+}
+
+// item returns the path of the repeatable child element at the index
+func item(path string, name string, index int) string {
+	return path + "/" + name + "[" + strconv.Itoa(index+1) + "]"
+}
+
+// https://cyber.law.harvard.edu/rss/rss.html#requiredChannelElements
+func (c *Channel) check(v *validator, path string) {
+	if strings.TrimSpace(c.Title) == "" {
+		v.error(path, "requiredChannelElements", "channel elements MUST "+
+			"contain a title element.")
+	}
+
+	if strings.TrimSpace(c.Link) == "" {
+		v.error(path, "requiredChannelElements", "channel elements MUST "+
+			"contain a link element.")
+	}
+
+	if strings.TrimSpace(c.Description) == "" {
+		v.error(path, "requiredChannelElements", "channel elements MUST "+
+			"contain a description element.")
+	}
+
+	// http://backend.userland.com/rss091#language
+	if v.spec.RequiredLanguage && strings.TrimSpace(c.Language) == "" {
+		v.error(path, "language", "channel elements MUST contain a language "+
+			"element.")
+	}
+
+	// https://cyber.law.harvard.edu/rss/rss.html#optionalChannelElements
+	v.checkEmail(path+"/managingEditor", c.ManagingEditor)
+	v.checkEmail(path+"/webMaster", c.WebMaster)
+	v.checkDate(path+"/pubDate", c.PubDate)
+	v.checkDate(path+"/lastBuildDate", c.LastBuildDate)
+	c.Cloud.check(v, path+"/cloud")
+	c.checkTTL(v, path+"/ttl")
+	c.checkSkipHours(v, path+"/skipHours")
+	c.Image.check(v, path+"/image")
+	c.TextInput.check(v, path+"/textInput")
+
+	for index := range c.Item {
+		c.Item[index].check(v, item(path, "item", index))
+	}
+
+	if c.IsPodcast() {
+		c.checkPodcast(v, path)
+	}
+}
+
+// IsPodcast returns true if the channel or one of its items has iTunes
+// elements. The feed is then checked against the requirements of Apple
+// Podcasts too.
+func (c *Channel) IsPodcast() bool {
+	if !reflect.DeepEqual(c.ITunes, itunes.Channel{}) {
+		return true
+	}
+
+	for index := range c.Item {
+		if !reflect.DeepEqual(c.Item[index].ITunes, itunes.Item{}) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// https://help.apple.com/itc/podcasts_connect/#/itcb54353390
+func (c *Channel) checkPodcast(v *validator, path string) {
+	if strings.TrimSpace(c.Language) == "" {
+		v.podcastError(path, "podcasts MUST contain a language element.")
+	}
+
+	if c.ITunes.Image == "" {
+		v.podcastError(path, "podcasts MUST contain an itunes:image element.")
+	} else if u, err := url.Parse(c.ITunes.Image); err != nil ||
+		u.Host == "" || (u.Scheme != "http" && u.Scheme != "https") ||
+		!artwork.MatchString(u.Path) {
+		v.podcastError(path+"/itunes:image", "itunes:image elements MUST "+
+			"have an href attribute with the http URL of a JPEG or PNG "+
+			"image, actual '"+c.ITunes.Image+"'.")
+	}
+
+	if len(c.ITunes.Categories) == 0 {
+		v.podcastError(path, "podcasts MUST contain an itunes:category "+
+			"element.")
+	}
+
+	if c.ITunes.Explicit == nil {
+		v.podcastError(path, "podcasts MUST contain an itunes:explicit "+
+			"element with 'true' or 'false'.")
+	}
+
+	if c.ITunes.Owner.Email == "" {
+		v.podcastWarning(path, "podcasts SHOULD contain an itunes:owner "+
+			"element with the email address of the owner.")
+	}
+
+	for index := range c.Item {
+		c.Item[index].checkEpisode(v, item(path, "item", index))
+	}
+}
+
+// checkEpisode verifies an item of a podcast
+// https://help.apple.com/itc/podcasts_connect/#/itcb54353390
+func (i *Item) checkEpisode(v *validator, path string) {
+	if i.Enclosure.URL == "" {
+		v.podcastError(path, "episodes MUST contain an enclosure element.")
+	}
+
+	if strings.TrimSpace(i.GUID.Content) == "" {
+		v.podcastWarning(path, "episodes SHOULD contain a guid element.")
+	}
+
+	switch i.ITunes.EpisodeType {
+	case "", "full", "trailer", "bonus":
+	default:
+		v.podcastError(path+"/itunes:episodeType", "itunes:episodeType "+
+			"elements MUST be 'full', 'trailer' or 'bonus', actual '"+
+			i.ITunes.EpisodeType+"'.")
+	}
+
+	if i.ITunes.InvalidEpisode != "" {
+		v.podcastError(path+"/itunes:episode", "itunes:episode elements "+
+			"MUST be a positive number, actual '"+i.ITunes.InvalidEpisode+"'.")
+	} else if i.ITunes.Episode < 0 {
+		v.podcastError(path+"/itunes:episode", "itunes:episode elements "+
+			"MUST be a positive number, actual '"+
+			strconv.Itoa(i.ITunes.Episode)+"'.")
+	}
+
+	if i.ITunes.InvalidSeason != "" {
+		v.podcastError(path+"/itunes:season", "itunes:season elements "+
+			"MUST be a positive number, actual '"+i.ITunes.InvalidSeason+"'.")
+	} else if i.ITunes.Season < 0 {
+		v.podcastError(path+"/itunes:season", "itunes:season elements "+
+			"MUST be a positive number, actual '"+
+			strconv.Itoa(i.ITunes.Season)+"'.")
+	}
+}
+
+// https://cyber.law.harvard.edu/rss/rss.html#ltttlgtSubelementOfLtchannelgt
+func (c *Channel) checkTTL(v *validator, path string) {
+	if c.InvalidTTL != "" {
+		v.error(path, "ltttlgtSubelementOfLtchannelgt", "ttl MUST be a "+
+			"number of minutes, actual '"+c.InvalidTTL+"'.")
+	}
+
+	if c.TTL < 0 {
+		v.error(path, "ltttlgtSubelementOfLtchannelgt", "ttl MUST be a "+
+			"number of minutes, actual '"+strconv.Itoa(c.TTL)+"'.")
+	}
+}
+
+// https://cyber.law.harvard.edu/rss/skipHoursDays.html#skiphours
+func (c *Channel) checkSkipHours(v *validator, path string) {
+	if len(c.SkipHours)+len(c.InvalidHours) > 24 {
+		v.error(path, "skiphours", "skipHours elements MUST contain up to 24 "+
+			"hour elements.")
+	}
+
+	for _, value := range c.InvalidHours {
+		v.error(path, "skiphours", "hour elements MUST be a number between "+
+			strconv.Itoa(v.spec.MinHour)+" and "+strconv.Itoa(v.spec.MaxHour)+
+			", actual '"+value+"'.")
+	}
+
+	seen := map[int]bool{}
+	for index, value := range c.SkipHours {
+		hourPath := item(path, "hour", index)
+
+		hour, ok := v.spec.Hour(value)
+		if !ok {
+			v.error(hourPath, "skiphours", "hour elements MUST be a number "+
+				"between "+strconv.Itoa(v.spec.MinHour)+" and "+
+				strconv.Itoa(v.spec.MaxHour)+", actual '"+
+				strconv.Itoa(value)+"'.")
+			continue
+		}
+
+		// https://www.rssboard.org/rss-profile#element-channel-skiphours
+		if seen[hour] {
+			v.warning(hourPath, "element-channel-skiphours", "hour elements "+
+				"SHOULD NOT be duplicated, actual '"+strconv.Itoa(value)+"'.")
+		}
+		seen[hour] = true
+	}
+}
+
+// https://cyber.law.harvard.edu/rss/rss.html#ltcloudgtSubelementOfLtchannelgt
+func (c *Cloud) check(v *validator, path string) {
+	if *c == (Cloud{}) {
+		return
+	}
+
+	if c.Domain == "" || c.Port <= 0 || c.Path == "" ||
+		c.RegisterProcedure == "" {
+		v.error(path, "ltcloudgtSubelementOfLtchannelgt", "cloud elements "+
+			"MUST have domain, port, path and registerProcedure attributes.")
+	}
+
+	if c.Protocol != "xml-rpc" && c.Protocol != "soap" &&
+		c.Protocol != "http-post" {
+		v.error(path, "ltcloudgtSubelementOfLtchannelgt", "cloud protocol "+
+			"MUST be 'xml-rpc', 'soap' or 'http-post', actual '"+c.Protocol+
+			"'.")
+	}
+}
+
+// https://cyber.law.harvard.edu/rss/rss.html#ltimagegtSubelementOfLtchannelgt
+func (i *Image) check(v *validator, path string) {
+	if *i == (Image{}) {
+		return
+	}
+
+	if i.URL == "" || i.Title == "" || i.Link == "" {
+		v.error(path, "ltimagegtSubelementOfLtchannelgt", "image elements "+
+			"MUST contain url, title and link elements.")
+	}
+
+	checkSize(v, path+"/width", i.Width, 144)
+	checkSize(v, path+"/height", i.Height, 400)
+}
+
+// checkSize verifies a dimension of the image, in pixels
+func checkSize(v *validator, path string, value int, max int) {
+	if value < 0 || value > max {
+		v.error(path, "ltimagegtSubelementOfLtchannelgt", "image sizes MUST "+
+			"be a number of pixels up to "+strconv.Itoa(max)+", actual '"+
+			strconv.Itoa(value)+"'.")
+	}
+}
+
+// https://cyber.law.harvard.edu/rss/rss.html#lttextinputgtSubelementOfLtchannelgt
+func (t *TextInput) check(v *validator, path string) {
+	if *t == (TextInput{}) {
+		return
+	}
+
+	if t.Title == "" || t.Description == "" || t.Name == "" || t.Link == "" {
+		v.error(path, "lttextinputgtSubelementOfLtchannelgt", "textInput "+
+			"elements MUST contain title, description, name and link "+
+			"elements.")
+	}
+}
+
+// https://cyber.law.harvard.edu/rss/rss.html#hrelementsOfLtitemgt
+func (i *Item) check(v *validator, path string) {
+	if strings.TrimSpace(i.Title) == "" &&
+		strings.TrimSpace(i.Description) == "" {
+		v.error(path, "hrelementsOfLtitemgt", "item elements MUST contain "+
+			"at least one of title or description.")
+	}
+
+	v.checkEmail(path+"/author", i.Author)
+	v.checkDate(path+"/pubDate", i.PubDate)
+	i.Enclosure.check(v, path+"/enclosure")
+	i.GUID.check(v, path+"/guid")
+
+	// https://cyber.law.harvard.edu/rss/rss.html#ltsourcegtSubelementOfLtitemgt
+	if i.Source != (Source{}) && i.Source.URL == "" {
+		v.error(path+"/source", "ltsourcegtSubelementOfLtitemgt", "source "+
+			"elements MUST have an url attribute.")
+	}
+}
+
+// https://cyber.law.harvard.edu/rss/rss.html#ltenclosuregtSubelementOfLtitemgt
+func (e *Enclosure) check(v *validator, path string) {
+	if *e == (Enclosure{}) {
+		return
+	}
+
+	section := "ltenclosuregtSubelementOfLtitemgt"
+	if !v.spec.Enclosure {
+		v.error(path, section, "enclosure elements are not allowed in RSS "+
+			v.spec.Version+".")
+		return
+	}
+
+	if u, err := url.Parse(e.URL); err != nil || u.Host == "" ||
+		(u.Scheme != "http" && u.Scheme != "https") {
+		v.error(path, section, "enclosure elements MUST have an url "+
+			"attribute with an http URL, actual '"+e.URL+"'.")
+	}
+
+	// The length is required, 0 when the size is unknown
+	// https://www.rssboard.org/rss-profile#element-channel-item-enclosure
+	if e.MissingLength {
+		v.error(path, section, "enclosure elements MUST have a length "+
+			"attribute, 0 if the size is unknown.")
+	}
+
+	if e.InvalidLength != "" {
+		v.error(path, section, "enclosure elements MUST have a length "+
+			"attribute with a size in bytes, actual '"+e.InvalidLength+"'.")
+	} else if e.Length < 0 {
+		v.error(path, section, "enclosure elements MUST have a length "+
+			"attribute with a size in bytes, actual '"+
+			strconv.FormatInt(e.Length, 10)+"'.")
+	}
+
+	if parts := strings.Split(e.Type, "/"); len(parts) != 2 ||
+		parts[0] == "" || parts[1] == "" {
+		v.error(path, section, "enclosure elements MUST have a type "+
+			"attribute with a MIME type, actual '"+e.Type+"'.")
+	}
+}
+
+// https://cyber.law.harvard.edu/rss/rss.html#ltguidgtSubelementOfLtitemgt
+func (g *GUID) check(v *validator, path string) {
+	if *g == (GUID{}) {
+		return
+	}
+
+	section := "ltguidgtSubelementOfLtitemgt"
+	content := strings.TrimSpace(g.Content)
+	if content == "" {
+		v.error(path, section, "guid elements MUST contain a string that "+
+			"uniquely identifies the item.")
+		return
+	}
+
+	isPermaLink := strings.TrimSpace(g.IsPermaLink)
+	if isPermaLink != "" && isPermaLink != "true" && isPermaLink != "false" {
+		v.error(path, section, "isPermaLink attributes MUST be 'true' or "+
+			"'false', actual '"+isPermaLink+"'.")
+		return
+	}
+
+	// https://www.rssboard.org/rss-profile#element-channel-item-guid
+	// isPermaLink is true by default, the guid is then the URL of the item
+	if isPermaLink != "false" {
+		if u, err := url.Parse(content); err != nil || !u.IsAbs() {
+			v.profileError(path, "element-channel-item-guid", "guid elements "+
+				"which are permalinks MUST contain the full URL of the item, "+
+				"actual '"+content+"'.")
+		}
+	}
+}
+
+// checkEmail verifies the email address of a person, e.g. managingEditor
+// https://www.rssboard.org/rss-profile#data-types-email
+func (v *validator) checkEmail(path string, value string) {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return
+	}
+
+	if !email.MatchString(value) {
+		v.error(path, "optionalChannelElements", "persons MUST be an email "+
+			"address, optionally followed by a name between parentheses, "+
+			"actual '"+value+"'.")
+		return
+	}
+
+	if !strings.Contains(value, "(") {
+		v.warning(path, "data-types-email", "email addresses SHOULD be "+
+			"followed by the name of the person between parentheses, "+
+			"actual '"+value+"'.")
+	}
+}
+
+// checkDate verifies a date-time, which MUST conform to RFC 822
+// https://www.rssboard.org/rss-profile#data-types-datetime
+func (v *validator) checkDate(path string, value string) {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return
+	}
+
+	if _, err := date.ParseRFC822(value); err != nil {
+		v.error(path, "optionalChannelElements", "dates MUST conform to the "+
+			"Date and Time Specification of RFC 822, actual '"+value+"'.")
+		return
+	}
+
+	if fields := strings.Fields(value); len(fields[len(fields)-3]) == 2 {
+		v.warning(path, "data-types-datetime", "years SHOULD be expressed "+
+			"as four digits, actual '"+value+"'.")
+	}
+}
@@ -0,0 +1,272 @@
+// This file is part of clutch.
+//
+// clutch is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// clutch is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with Foobar.  If not, see <http://www.gnu.org/licenses/>.
+
+package rss
+
+import (
+	"io/ioutil"
+	"testing"
+
+	"github.com/racam/clutch/itunes"
+	"github.com/racam/clutch/validation"
+)
+
+func TestValidate(t *testing.T) {
+	valid := func() *RSS {
+		r := RSS{Version: Version20}
+		r.Channel.Title = "Title"
+		r.Channel.Link = "http://example.org/"
+		r.Channel.Description = "Description"
+		r.Channel.ManagingEditor = "editor@example.org (Editor)"
+		r.Channel.PubDate = "Mon, 02 Jan 2006 15:04:05 +0100"
+		r.Channel.TTL = 60
+		r.Channel.SkipHours = []int{0, 23}
+		r.Channel.Item = []Item{{
+			Title: "Item",
+			Enclosure: Enclosure{URL: "http://example.org/a.mp3",
+				Length: 1024, Type: "audio/mpeg"},
+			GUID: GUID{Content: "http://example.org/1"},
+		}}
+		return &r
+	}
+
+	var feeds = []struct {
+		name     string       // name of the case
+		update   func(r *RSS) // invalidates the valid feed
+		path     string       // expected location of the violation
+		severity validation.Severity
+	}{
+		{"title", func(r *RSS) { r.Channel.Title = "" }, "/rss/channel",
+			validation.SeverityError},
+		{"language 0.91", func(r *RSS) {
+			r.Version = Version091
+			r.Channel.SkipHours = nil
+			r.Channel.Item[0].Enclosure = Enclosure{}
+		}, "/rss/channel", validation.SeverityError},
+		{"enclosure 0.91", func(r *RSS) {
+			r.Version = Version091
+			r.Channel.Language = "en"
+			r.Channel.SkipHours = nil
+		}, "/rss/channel/item[1]/enclosure", validation.SeverityError},
+		{"item", func(r *RSS) { r.Channel.Item[0].Title = "" },
+			"/rss/channel/item[1]", validation.SeverityError},
+		{"enclosure url", func(r *RSS) {
+			r.Channel.Item[0].Enclosure.URL = "a.mp3"
+		}, "/rss/channel/item[1]/enclosure", validation.SeverityError},
+		{"enclosure length", func(r *RSS) {
+			r.Channel.Item[0].Enclosure.Length = -1
+		}, "/rss/channel/item[1]/enclosure", validation.SeverityError},
+		{"enclosure length missing", func(r *RSS) {
+			r.Channel.Item[0].Enclosure.MissingLength = true
+		}, "/rss/channel/item[1]/enclosure", validation.SeverityError},
+		{"enclosure length not integer", func(r *RSS) {
+			r.Channel.Item[0].Enclosure.InvalidLength = "big"
+		}, "/rss/channel/item[1]/enclosure", validation.SeverityError},
+		{"enclosure type", func(r *RSS) {
+			r.Channel.Item[0].Enclosure.Type = "mp3"
+		}, "/rss/channel/item[1]/enclosure", validation.SeverityError},
+		{"ttl", func(r *RSS) { r.Channel.TTL = -1 }, "/rss/channel/ttl",
+			validation.SeverityError},
+		{"ttl not integer", func(r *RSS) { r.Channel.InvalidTTL = "sixty" },
+			"/rss/channel/ttl", validation.SeverityError},
+		{"skipHours not integer", func(r *RSS) {
+			r.Channel.InvalidHours = []string{"noon"}
+		}, "/rss/channel/skipHours", validation.SeverityError},
+		{"skipHours", func(r *RSS) { r.Channel.SkipHours[1] = 24 },
+			"/rss/channel/skipHours/hour[2]", validation.SeverityError},
+		{"skipHours duplicated", func(r *RSS) { r.Channel.SkipHours[1] = 0 },
+			"/rss/channel/skipHours/hour[2]", validation.SeverityWarning},
+		{"cloud", func(r *RSS) {
+			r.Channel.Cloud = Cloud{Domain: "rpc.example.org", Port: 80,
+				Path: "/RPC2", RegisterProcedure: "pingMe", Protocol: "rest"}
+		}, "/rss/channel/cloud", validation.SeverityError},
+		{"date", func(r *RSS) { r.Channel.PubDate = "2006-01-02" },
+			"/rss/channel/pubDate", validation.SeverityError},
+		{"date year", func(r *RSS) {
+			r.Channel.PubDate = "02 Jan 06 15:04 EST"
+		}, "/rss/channel/pubDate", validation.SeverityWarning},
+		{"email", func(r *RSS) { r.Channel.ManagingEditor = "Editor" },
+			"/rss/channel/managingEditor", validation.SeverityError},
+		{"email name", func(r *RSS) {
+			r.Channel.ManagingEditor = "editor@example.org"
+		}, "/rss/channel/managingEditor", validation.SeverityWarning},
+		{"guid permalink", func(r *RSS) {
+			r.Channel.Item[0].GUID.Content = "1"
+		}, "/rss/channel/item[1]/guid", validation.SeverityError},
+		{"guid isPermaLink", func(r *RSS) {
+			r.Channel.Item[0].GUID.IsPermaLink = "yes"
+		}, "/rss/channel/item[1]/guid", validation.SeverityError},
+	}
+
+	if report := Validate(valid()); len(report.Violations) != 0 {
+		t.Errorf("[RSS][Unit][Validate] valid feed : unexpected\n%s", report)
+	}
+
+	for _, f := range feeds {
+		r := valid()
+		f.update(r)
+
+		report := Validate(r)
+		if len(report.Violations) != 1 || report.Violations[0].Path != f.path ||
+			report.Violations[0].Severity != f.severity {
+			t.Errorf("[RSS][Unit][Validate] %s : expected a violation at '%s', "+
+				"actual\n%s", f.name, f.path, report)
+		}
+	}
+
+	r := valid()
+	r.Channel.Item[0].GUID.IsPermaLink = "false"
+	r.Channel.Item[0].GUID.Content = "1"
+	if err := Check(r); err != nil {
+		t.Errorf("[RSS][Unit][Check] guid not permalink : unexpected %s", err)
+	}
+}
+
+func TestValidateDocument(t *testing.T) {
+	filename := "unit_06_check_report.xml"
+	data, err := ioutil.ReadFile("../testdata/rss/" + filename)
+	if err != nil {
+		t.Fatalf("[RSS][Unit][ValidateDocument] file '%s' : is missing",
+			filename)
+	}
+
+	report, err := ValidateDocument(data)
+	if err != nil {
+		t.Fatalf("[RSS][Unit][ValidateDocument] file '%s' : %s", filename, err)
+	}
+
+	var expected = []struct {
+		path string // location of the violation
+		line int    // line of the element or of its parent
+	}{
+		{"/rss/channel", 7},
+		{"/rss/channel", 7},
+		{"/rss/channel/ttl", 9},
+		{"/rss/channel/skipHours", 10},
+		{"/rss/channel/item[1]/enclosure", 15},
+		{"/rss/channel/item[1]/guid", 16},
+		{"/rss/channel/item[2]", 18},
+		{"/rss/channel/item[2]/author", 19},
+		{"/rss/channel/item[2]/enclosure", 20},
+		{"/rss/channel/item[3]/enclosure", 24},
+	}
+
+	if len(report.Violations) != len(expected) {
+		t.Fatalf("[RSS][Unit][ValidateDocument] expected %d violations, "+
+			"actual :\n%s", len(expected), report)
+	}
+
+	for index, e := range expected {
+		v := report.Violations[index]
+		if v.Path != e.path || v.Line != e.line {
+			t.Errorf("[RSS][Unit][ValidateDocument] violation %d : expected "+
+				"%+v, actual %+v", index, e, v)
+		}
+	}
+
+	if err := Check(&RSS{}); err == nil {
+		t.Errorf("[RSS][Unit][Check] empty feed : expected an error")
+	}
+}
+
+func TestValidatePodcast(t *testing.T) {
+	valid := func() *RSS {
+		explicit := false
+		r := RSS{Version: Version20}
+		r.Channel.Title = "Podcast"
+		r.Channel.Link = "http://example.org/"
+		r.Channel.Description = "Episodes"
+		r.Channel.Language = "en"
+		r.Channel.ITunes = itunes.Channel{
+			Categories: []itunes.Category{{Text: "Technology"}},
+			Explicit:   &explicit,
+			Image:      "http://example.org/podcast.JPG",
+			Owner:      itunes.Owner{Email: "john@example.org"},
+		}
+		r.Channel.Item = []Item{{
+			Title: "Episode 1",
+			Enclosure: Enclosure{URL: "http://example.org/1.mp3",
+				Length: 1024, Type: "audio/mpeg"},
+			GUID:   GUID{Content: "http://example.org/1"},
+			ITunes: itunes.Item{EpisodeType: "full", Episode: 1, Season: 1},
+		}}
+		return &r
+	}
+
+	var feeds = []struct {
+		name     string       // name of the case
+		update   func(r *RSS) // invalidates the valid feed
+		path     string       // expected location of the violation
+		severity validation.Severity
+	}{
+		{"language", func(r *RSS) { r.Channel.Language = "" }, "/rss/channel",
+			validation.SeverityError},
+		{"image", func(r *RSS) { r.Channel.ITunes.Image = "" }, "/rss/channel",
+			validation.SeverityError},
+		{"image format", func(r *RSS) {
+			r.Channel.ITunes.Image = "http://example.org/podcast.gif"
+		}, "/rss/channel/itunes:image", validation.SeverityError},
+		{"category", func(r *RSS) { r.Channel.ITunes.Categories = nil },
+			"/rss/channel", validation.SeverityError},
+		{"explicit", func(r *RSS) { r.Channel.ITunes.Explicit = nil },
+			"/rss/channel", validation.SeverityError},
+		{"owner", func(r *RSS) { r.Channel.ITunes.Owner = itunes.Owner{} },
+			"/rss/channel", validation.SeverityWarning},
+		{"enclosure", func(r *RSS) { r.Channel.Item[0].Enclosure = Enclosure{} },
+			"/rss/channel/item[1]", validation.SeverityError},
+		{"guid", func(r *RSS) { r.Channel.Item[0].GUID = GUID{} },
+			"/rss/channel/item[1]", validation.SeverityWarning},
+		{"episodeType", func(r *RSS) {
+			r.Channel.Item[0].ITunes.EpisodeType = "teaser"
+		}, "/rss/channel/item[1]/itunes:episodeType", validation.SeverityError},
+		{"episode", func(r *RSS) { r.Channel.Item[0].ITunes.Episode = -1 },
+			"/rss/channel/item[1]/itunes:episode", validation.SeverityError},
+		{"season", func(r *RSS) { r.Channel.Item[0].ITunes.Season = -2 },
+			"/rss/channel/item[1]/itunes:season", validation.SeverityError},
+		{"episode not integer", func(r *RSS) {
+			r.Channel.Item[0].ITunes.InvalidEpisode = "one"
+		}, "/rss/channel/item[1]/itunes:episode", validation.SeverityError},
+		{"season not integer", func(r *RSS) {
+			r.Channel.Item[0].ITunes.InvalidSeason = "first"
+		}, "/rss/channel/item[1]/itunes:season", validation.SeverityError},
+	}
+
+	if report := Validate(valid()); len(report.Violations) != 0 {
+		t.Errorf("[RSS][Unit][Validate] valid podcast : unexpected\n%s", report)
+	}
+
+	for _, f := range feeds {
+		r := valid()
+		f.update(r)
+
+		report := Validate(r)
+		if len(report.Violations) != 1 || report.Violations[0].Path != f.path ||
+			report.Violations[0].Severity != f.severity ||
+			report.Violations[0].Spec != "Apple Podcasts" {
+			t.Errorf("[RSS][Unit][Validate] podcast %s : expected a violation "+
+				"at '%s', actual\n%s", f.name, f.path, report)
+		}
+	}
+
+	// A feed without iTunes elements is not a podcast
+	r := valid()
+	r.Channel.Language = ""
+	r.Channel.ITunes = itunes.Channel{}
+	r.Channel.Item[0].ITunes = itunes.Item{}
+	if report := Validate(r); r.Channel.IsPodcast() ||
+		len(report.Violations) != 0 {
+		t.Errorf("[RSS][Unit][Validate] not a podcast : unexpected\n%s", report)
+	}
+}
@@ -0,0 +1,40 @@
+// This file is part of clutch.
+//
+// clutch is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// clutch is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with Foobar.  If not, see <http://www.gnu.org/licenses/>.
+
+package rss
+
+import (
+	"time"
+
+	"github.com/racam/clutch/date"
+)
+
+// PubDateTime returns the publication date of the channel, the date is read
+// by date.Parse which accepts the broken dates found in the wild
+func (c *Channel) PubDateTime() (time.Time, error) {
+	return date.Parse(c.PubDate)
+}
+
+// LastBuildDateTime returns the last build date of the channel, see
+// PubDateTime
+func (c *Channel) LastBuildDateTime() (time.Time, error) {
+	return date.Parse(c.LastBuildDate)
+}
+
+// PubDateTime returns the publication date of the item, see
+// Channel.PubDateTime
+func (i *Item) PubDateTime() (time.Time, error) {
+	return date.Parse(i.PubDate)
+}
@@ -0,0 +1,466 @@
+// This file is part of clutch.
+//
+// clutch is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// clutch is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with Foobar.  If not, see <http://www.gnu.org/licenses/>.
+
+package rss
+
+import (
+	"encoding/xml"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/racam/clutch/content"
+	"github.com/racam/clutch/dc"
+	"github.com/racam/clutch/ext"
+	"github.com/racam/clutch/internal/xmldec"
+	"github.com/racam/clutch/itunes"
+	"github.com/racam/clutch/media"
+	"github.com/racam/clutch/podcast"
+)
+
+// names maps the lower case names of the RSS elements and attributes to their
+// name in the specification. encoding/xml is case-sensitive and many
+// publishers write pubdate or PubDate.
+var names = lowerNames("author", "category", "channel", "cloud", "comments",
+	"copyright", "day", "description", "docs", "domain", "enclosure",
+	"generator", "guid", "height", "hour", "image", "isPermaLink", "item",
+	"language", "lastBuildDate", "length", "link", "managingEditor", "name",
+	"path", "port", "protocol", "pubDate", "rating", "registerProcedure",
+	"skipDays", "skipHours", "source", "textInput", "title", "ttl", "type",
+	"url", "version", "webMaster", "width")
+
+func lowerNames(values ...string) map[string]string {
+	res := make(map[string]string, len(values))
+	for _, v := range values {
+		res[strings.ToLower(v)] = v
+	}
+
+	return res
+}
+
+// canonical returns the name of the specification for the name of an
+// element or an attribute, whatever its case. Unknown names are returned as
+// they are.
+func canonical(name string) string {
+	if c, ok := names[strings.ToLower(name)]; ok {
+		return c
+	}
+
+	return name
+}
+
+// decodeElement decodes the children of the element start until its end with
+// xmldec.DecodeChildren. The RSS elements, which are in the namespace of their
+// parent (usually no namespace), are decoded into the value returned by field
+// for their name in the specification. The attributes without namespace are
+// renamed the same way. The elements of the modules, in another namespace, are
+// decoded into the value returned by module, which may be nil.
+func decodeElement(d *xml.Decoder, start xml.StartElement,
+	field func(name string) interface{},
+	module func(name xml.Name) interface{}) error {
+	var other func(name xml.Name) interface{}
+	if module != nil {
+		other = func(name xml.Name) interface{} {
+			return canonicalized(module(name))
+		}
+	}
+
+	return xmldec.DecodeChildren(d, xmldec.InNamespace(start.Name.Space),
+		func(local string) interface{} {
+			return canonicalized(field(canonical(local)))
+		}, other)
+}
+
+// canonicalValue decodes an element into v with its attributes renamed like
+// the specification, see canonicalAttr
+type canonicalValue struct {
+	v interface{}
+}
+
+// canonicalized returns v wrapped in a canonicalValue, or nil when v is nil
+func canonicalized(v interface{}) interface{} {
+	if v == nil {
+		return nil
+	}
+
+	return &canonicalValue{v}
+}
+
+// UnmarshalXML decodes the element into the wrapped value
+func (c *canonicalValue) UnmarshalXML(d *xml.Decoder,
+	start xml.StartElement) error {
+	start = canonicalAttr(start)
+	return d.DecodeElement(c.v, &start)
+}
+
+// canonicalAttr returns the element with the attributes without namespace
+// renamed like the specification
+func canonicalAttr(start xml.StartElement) xml.StartElement {
+	attrs := make([]xml.Attr, len(start.Attr))
+	for index, a := range start.Attr {
+		if a.Name.Space == "" {
+			a.Name.Local = canonical(a.Name.Local)
+		}
+		attrs[index] = a
+	}
+	start.Attr = attrs
+
+	return start
+}
+
+// UnmarshalXML decodes the rss element, see decodeElement
+func (r *RSS) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
+	if !strings.EqualFold(start.Name.Local, "rss") {
+		return xml.UnmarshalError("expected element type <rss> but have <" +
+			start.Name.Local + ">")
+	}
+
+	r.XMLName = start.Name
+	r.Version = DeclaredVersion(start)
+
+	return decodeElement(d, start, func(name string) interface{} {
+		if name == "channel" {
+			return &r.Channel
+		}
+		return nil
+	}, nil)
+}
+
+// UnmarshalXML decodes the channel element, see decodeElement
+func (c *Channel) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
+	return decodeElement(d, start, func(name string) interface{} {
+		switch name {
+		case "category":
+			c.Category = append(c.Category, Category{})
+			return &c.Category[len(c.Category)-1]
+		case "cloud":
+			return &c.Cloud
+		case "copyright":
+			return &c.Copyright
+		case "description":
+			return &c.Description
+		case "docs":
+			return &c.Docs
+		case "generator":
+			return &c.Generator
+		case "image":
+			return &c.Image
+		case "item":
+			c.Item = append(c.Item, Item{})
+			return &c.Item[len(c.Item)-1]
+		case "language":
+			return &c.Language
+		case "lastBuildDate":
+			return &c.LastBuildDate
+		case "link":
+			return &c.Link
+		case "managingEditor":
+			return &c.ManagingEditor
+		case "pubDate":
+			return &c.PubDate
+		case "rating":
+			return &c.Rating
+		case "skipDays":
+			return (*days)(&c.SkipDays)
+		case "skipHours":
+			return &hours{&c.SkipHours, &c.InvalidHours}
+		case "textInput":
+			return &c.TextInput
+		case "title":
+			return &c.Title
+		case "ttl":
+			return &number{&c.TTL, &c.InvalidTTL}
+		case "webMaster":
+			return &c.WebMaster
+		}
+		return nil
+	}, c.module)
+}
+
+// module returns the value of an element of the modules of the channel, see
+// ext.Modules. The elements of the unknown namespaces are kept in the
+// extensions.
+func (c *Channel) module(name xml.Name) interface{} {
+	if name == (xml.Name{Space: podcast.Namespace, Local: "liveItem"}) ||
+		name == (xml.Name{Space: podcast.NamespaceGitHub, Local: "liveItem"}) {
+		c.LiveItem = append(c.LiveItem, LiveItem{})
+		return &c.LiveItem[len(c.LiveItem)-1]
+	}
+
+	if v := c.Modules.Element(name, c.fields); v != nil {
+		return v
+	}
+
+	return c.Extensions.Element(name)
+}
+
+// fields returns the module of the namespace kept in a field of the channel,
+// see ext.Modules
+func (c *Channel) fields(space string) ext.Module {
+	switch space {
+	case dc.Namespace, dc.NamespaceTerms:
+		return &c.DublinCore
+	case itunes.Namespace:
+		return &c.ITunes
+	case podcast.Namespace, podcast.NamespaceGitHub:
+		return &c.Podcast
+	}
+
+	return nil
+}
+
+// number is an integer element. The feeds are read leniently : a value which
+// is not an integer is decoded as 0 instead of failing the whole document. It
+// is kept in invalid, when there is one, for the check step.
+type number struct {
+	value   *int
+	invalid *string
+}
+
+// UnmarshalXML decodes an integer element
+func (n *number) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
+	var value string
+	if err := d.DecodeElement(&value, &start); err != nil {
+		return err
+	}
+
+	value = strings.TrimSpace(value)
+	i, err := strconv.Atoi(value)
+	if err != nil && value != "" && n.invalid != nil {
+		*n.invalid = value
+	}
+
+	*n.value = i
+	return nil
+}
+
+// hours are the hour elements of skipHours, as written in the document. The
+// hours which are not integers are kept in invalid for the check step.
+type hours struct {
+	value   *[]int
+	invalid *[]string
+}
+
+// UnmarshalXML decodes the skipHours element, see decodeElement
+func (h *hours) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
+	var values []string
+
+	err := decodeElement(d, start, func(name string) interface{} {
+		if name == "hour" {
+			values = append(values, "")
+			return &values[len(values)-1]
+		}
+		return nil
+	}, nil)
+
+	for _, v := range values {
+		v = strings.TrimSpace(v)
+		if hour, err := strconv.Atoi(v); err == nil {
+			*h.value = append(*h.value, hour)
+		} else {
+			*h.invalid = append(*h.invalid, v)
+		}
+	}
+
+	return err
+}
+
+// days are the day elements of skipDays. The days which are not English
+// day names, whatever their case, are dropped.
+type days []time.Weekday
+
+// UnmarshalXML decodes the skipDays element, see decodeElement
+func (w *days) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
+	var values []string
+
+	err := decodeElement(d, start, func(name string) interface{} {
+		if name == "day" {
+			values = append(values, "")
+			return &values[len(values)-1]
+		}
+		return nil
+	}, nil)
+
+	for _, v := range values {
+		for day := time.Sunday; day <= time.Saturday; day++ {
+			if strings.EqualFold(strings.TrimSpace(v), day.String()) {
+				*w = append(*w, day)
+			}
+		}
+	}
+
+	return err
+}
+
+// UnmarshalXML decodes the cloud element, a port which is not an integer is
+// decoded as 0
+func (c *Cloud) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
+	for _, a := range start.Attr {
+		switch a.Name.Local {
+		case "domain":
+			c.Domain = a.Value
+		case "path":
+			c.Path = a.Value
+		case "port":
+			c.Port, _ = strconv.Atoi(strings.TrimSpace(a.Value))
+		case "protocol":
+			c.Protocol = a.Value
+		case "registerProcedure":
+			c.RegisterProcedure = a.Value
+		}
+	}
+
+	return d.Skip()
+}
+
+// UnmarshalXML decodes the enclosure element, a missing length or a length
+// which is not an integer is decoded as 0 and recorded for the check step
+func (e *Enclosure) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
+	e.MissingLength = true
+	for _, a := range start.Attr {
+		switch a.Name.Local {
+		case "length":
+			value := strings.TrimSpace(a.Value)
+			length, err := strconv.ParseInt(value, 10, 64)
+			if err != nil && value != "" {
+				e.InvalidLength = value
+			}
+			e.Length = length
+			e.MissingLength = value == ""
+		case "type":
+			e.Type = a.Value
+		case "url":
+			e.URL = a.Value
+		}
+	}
+
+	return d.Skip()
+}
+
+// UnmarshalXML decodes the item element, see decodeElement
+func (i *Item) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
+	return decodeElement(d, start, func(name string) interface{} {
+		switch name {
+		case "author":
+			return &i.Author
+		case "category":
+			i.Category = append(i.Category, Category{})
+			return &i.Category[len(i.Category)-1]
+		case "comments":
+			return &i.Comments
+		case "description":
+			return &i.Description
+		case "enclosure":
+			return &i.Enclosure
+		case "guid":
+			return &i.GUID
+		case "link":
+			return &i.Link
+		case "pubDate":
+			return &i.PubDate
+		case "source":
+			return &i.Source
+		case "title":
+			return &i.Title
+		}
+		return nil
+	}, i.module)
+}
+
+// module returns the value of an element of the modules of the item, see
+// ext.Modules. The elements of the unknown namespaces are kept in the
+// extensions.
+func (i *Item) module(name xml.Name) interface{} {
+	if v := i.Modules.Element(name, i.fields); v != nil {
+		return v
+	}
+
+	return i.Extensions.Element(name)
+}
+
+// fields returns the module of the namespace kept in a field of the item, see
+// ext.Modules
+func (i *Item) fields(space string) ext.Module {
+	switch space {
+	case content.Namespace:
+		return (*content.Encoded)(&i.Content)
+	case dc.Namespace, dc.NamespaceTerms:
+		return &i.DublinCore
+	case itunes.Namespace:
+		return &i.ITunes
+	case media.Namespace:
+		return &i.Media
+	case podcast.Namespace, podcast.NamespaceGitHub:
+		return &i.Podcast
+	}
+
+	return nil
+}
+
+// UnmarshalXML decodes the podcast:liveItem element. Its children are the
+// elements of an item, without namespace.
+func (l *LiveItem) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
+	for _, a := range start.Attr {
+		switch a.Name.Local {
+		case "end":
+			l.End = a.Value
+		case "start":
+			l.Start = a.Value
+		case "status":
+			l.Status = a.Value
+		}
+	}
+
+	start.Name = xml.Name{Local: "item"}
+	return l.Item.UnmarshalXML(d, start)
+}
+
+// UnmarshalXML decodes the image element, see decodeElement
+func (i *Image) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
+	return decodeElement(d, start, func(name string) interface{} {
+		switch name {
+		case "description":
+			return &i.Description
+		case "height":
+			return &number{value: &i.Height}
+		case "link":
+			return &i.Link
+		case "title":
+			return &i.Title
+		case "url":
+			return &i.URL
+		case "width":
+			return &number{value: &i.Width}
+		}
+		return nil
+	}, nil)
+}
+
+// UnmarshalXML decodes the textInput element, see decodeElement
+func (t *TextInput) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
+	return decodeElement(d, start, func(name string) interface{} {
+		switch name {
+		case "description":
+			return &t.Description
+		case "link":
+			return &t.Link
+		case "name":
+			return &t.Name
+		case "title":
+			return &t.Title
+		}
+		return nil
+	}, nil)
+}
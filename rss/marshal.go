@@ -0,0 +1,298 @@
+// This file is part of clutch.
+//
+// clutch is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// clutch is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with Foobar.  If not, see <http://www.gnu.org/licenses/>.
+
+package rss
+
+import (
+	"bytes"
+	"encoding/xml"
+	"io"
+	"strconv"
+
+	"github.com/racam/clutch/content"
+	"github.com/racam/clutch/date"
+	"github.com/racam/clutch/dc"
+	"github.com/racam/clutch/internal/xmlenc"
+	"github.com/racam/clutch/itunes"
+	"github.com/racam/clutch/media"
+	"github.com/racam/clutch/podcast"
+)
+
+// Marshal returns the RSS document of the feed. See Write.
+func Marshal(r *RSS) ([]byte, error) {
+	var buf bytes.Buffer
+
+	if err := Write(&buf, r); err != nil {
+		return nil, err
+	}
+
+	return buf.Bytes(), nil
+}
+
+// prefixes are the usual prefixes of the modules, declared on the rss element
+// for the ones the channel uses
+var prefixes = []xmlenc.Prefix{
+	{Name: "content", Space: content.Namespace},
+	{Name: "dc", Space: dc.Namespace},
+	{Name: "dcterms", Space: dc.NamespaceTerms},
+	{Name: "itunes", Space: itunes.Namespace},
+	{Name: "media", Space: media.Namespace},
+	{Name: "podcast", Space: podcast.Namespace},
+}
+
+// Write writes the RSS document of the feed to w. The elements are written
+// with the names of the specification, the empty ones are omitted. The version
+// attribute is 2.0 if the feed does not have one. The elements of the modules
+// are written with their usual prefix, declared on the rss element.
+// source : https://cyber.law.harvard.edu/rss/rss.html
+func Write(w io.Writer, r *RSS) error {
+	enc := xmlenc.NewEncoder(w)
+
+	version := r.Version
+	if version == "" {
+		version = Version20
+	}
+
+	enc.Bind(r.Channel.write, prefixes...)
+
+	n := xml.Name{Local: "rss"}
+	enc.Start(n, xmlenc.Attr("version", version))
+	r.Channel.write(enc)
+	enc.End(n)
+
+	return enc.Close()
+}
+
+// https://cyber.law.harvard.edu/rss/rss.html#requiredChannelElements
+func (c *Channel) write(enc *xmlenc.Encoder) {
+	n := xml.Name{Local: "channel"}
+	enc.Start(n)
+
+	enc.Element("title", c.Title)
+	enc.Element("link", c.Link)
+	enc.Element("description", c.Description)
+	enc.Element("language", c.Language)
+	enc.Element("copyright", c.Copyright)
+	enc.Element("managingEditor", c.ManagingEditor)
+	enc.Element("webMaster", c.WebMaster)
+	enc.Element("pubDate", date.RFC1123(c.PubDate))
+	enc.Element("lastBuildDate", date.RFC1123(c.LastBuildDate))
+
+	for index := range c.Category {
+		c.Category[index].write(enc)
+	}
+
+	enc.Element("generator", c.Generator)
+	enc.Element("docs", c.Docs)
+	c.Cloud.write(enc)
+	enc.Element("ttl", itoa(c.TTL))
+	c.Image.write(enc)
+	enc.Element("rating", c.Rating)
+	c.TextInput.write(enc)
+	c.writeSkipHours(enc)
+	c.writeSkipDays(enc)
+	c.DublinCore.Write(enc)
+	c.ITunes.Write(enc)
+	c.Podcast.Write(enc)
+	c.Modules.Write(enc)
+	c.Extensions.Write(enc)
+
+	for index := range c.Item {
+		c.Item[index].write(enc)
+	}
+
+	for index := range c.LiveItem {
+		c.LiveItem[index].write(enc)
+	}
+
+	enc.End(n)
+}
+
+// https://cyber.law.harvard.edu/rss/rss.html#hrelementsOfLtitemgt
+func (i *Item) write(enc *xmlenc.Encoder) {
+	n := xml.Name{Local: "item"}
+	enc.Start(n)
+	i.writeElements(enc)
+	enc.End(n)
+}
+
+// https://podcastindex.org/namespace/1.0#live-item
+// The children of a liveItem are not in the podcast namespace, which is bound
+// to the podcast prefix by Write instead of being the default namespace.
+func (l *LiveItem) write(enc *xmlenc.Encoder) {
+	n := xml.Name{Space: podcast.Namespace, Local: "liveItem"}
+	enc.Start(n, xmlenc.Attr("status", l.Status),
+		xmlenc.Attr("start", l.Start), xmlenc.Attr("end", l.End))
+	l.Item.writeElements(enc)
+	enc.End(n)
+}
+
+// writeElements writes the children of an item
+func (i *Item) writeElements(enc *xmlenc.Encoder) {
+	enc.Element("title", i.Title)
+	enc.Element("link", i.Link)
+	enc.Element("description", i.Description)
+	enc.Element("author", i.Author)
+
+	for index := range i.Category {
+		i.Category[index].write(enc)
+	}
+
+	enc.Element("comments", i.Comments)
+	i.Enclosure.write(enc)
+	i.GUID.write(enc)
+	enc.Element("pubDate", date.RFC1123(i.PubDate))
+	i.Source.write(enc)
+
+	// https://web.resource.org/rss/1.0/modules/content/#encoded
+	(*content.Encoded)(&i.Content).Write(enc)
+
+	i.DublinCore.Write(enc)
+	i.ITunes.Write(enc)
+	i.Media.Write(enc)
+	i.Podcast.Write(enc)
+	i.Modules.Write(enc)
+	i.Extensions.Write(enc)
+}
+
+// https://cyber.law.harvard.edu/rss/rss.html#ltimagegtSubelementOfLtchannelgt
+func (i *Image) write(enc *xmlenc.Encoder) {
+	if *i == (Image{}) {
+		return
+	}
+
+	n := xml.Name{Local: "image"}
+	enc.Start(n)
+	enc.Element("url", i.URL)
+	enc.Element("title", i.Title)
+	enc.Element("link", i.Link)
+	enc.Element("width", itoa(i.Width))
+	enc.Element("height", itoa(i.Height))
+	enc.Element("description", i.Description)
+	enc.End(n)
+}
+
+// https://cyber.law.harvard.edu/rss/rss.html#lttextinputgtSubelementOfLtchannelgt
+func (t *TextInput) write(enc *xmlenc.Encoder) {
+	if *t == (TextInput{}) {
+		return
+	}
+
+	n := xml.Name{Local: "textInput"}
+	enc.Start(n)
+	enc.Element("title", t.Title)
+	enc.Element("description", t.Description)
+	enc.Element("name", t.Name)
+	enc.Element("link", t.Link)
+	enc.End(n)
+}
+
+// https://cyber.law.harvard.edu/rss/skipHoursDays.html#skiphours
+func (c *Channel) writeSkipHours(enc *xmlenc.Encoder) {
+	if len(c.SkipHours) == 0 {
+		return
+	}
+
+	n := xml.Name{Local: "skipHours"}
+	enc.Start(n)
+	for _, hour := range c.SkipHours {
+		enc.Element("hour", strconv.Itoa(hour))
+	}
+	enc.End(n)
+}
+
+// https://cyber.law.harvard.edu/rss/skipHoursDays.html#skipdays
+func (c *Channel) writeSkipDays(enc *xmlenc.Encoder) {
+	if len(c.SkipDays) == 0 {
+		return
+	}
+
+	n := xml.Name{Local: "skipDays"}
+	enc.Start(n)
+	for _, day := range c.SkipDays {
+		enc.Element("day", day.String())
+	}
+	enc.End(n)
+}
+
+// https://cyber.law.harvard.edu/rss/rss.html#ltcloudgtSubelementOfLtchannelgt
+func (c *Cloud) write(enc *xmlenc.Encoder) {
+	if *c == (Cloud{}) {
+		return
+	}
+
+	n := xml.Name{Local: "cloud"}
+	enc.Start(n, xmlenc.Attr("domain", c.Domain),
+		xmlenc.Attr("port", itoa(c.Port)), xmlenc.Attr("path", c.Path),
+		xmlenc.Attr("registerProcedure", c.RegisterProcedure),
+		xmlenc.Attr("protocol", c.Protocol))
+	enc.End(n)
+}
+
+// https://cyber.law.harvard.edu/rss/rss.html#ltenclosuregtSubelementOfLtitemgt
+func (e *Enclosure) write(enc *xmlenc.Encoder) {
+	if *e == (Enclosure{}) {
+		return
+	}
+
+	n := xml.Name{Local: "enclosure"}
+	// The length is required, 0 when it is unknown
+	enc.Start(n, xmlenc.Attr("url", e.URL),
+		xmlenc.Attr("length", strconv.FormatInt(e.Length, 10)),
+		xmlenc.Attr("type", e.Type))
+	enc.End(n)
+}
+
+// https://cyber.law.harvard.edu/rss/rss.html#ltcategorygtSubelementOfLtitemgt
+func (c *Category) write(enc *xmlenc.Encoder) {
+	n := xml.Name{Local: "category"}
+	enc.Start(n, xmlenc.Attr("domain", c.Domain))
+	enc.Text(c.Content)
+	enc.End(n)
+}
+
+// https://cyber.law.harvard.edu/rss/rss.html#ltguidgtSubelementOfLtitemgt
+func (g *GUID) write(enc *xmlenc.Encoder) {
+	if *g == (GUID{}) {
+		return
+	}
+
+	n := xml.Name{Local: "guid"}
+	enc.Start(n, xmlenc.Attr("isPermaLink", g.IsPermaLink))
+	enc.Text(g.Content)
+	enc.End(n)
+}
+
+// https://cyber.law.harvard.edu/rss/rss.html#ltsourcegtSubelementOfLtitemgt
+func (s *Source) write(enc *xmlenc.Encoder) {
+	if *s == (Source{}) {
+		return
+	}
+
+	n := xml.Name{Local: "source"}
+	enc.Start(n, xmlenc.Attr("url", s.URL))
+	enc.Text(s.Title)
+	enc.End(n)
+}
+
+// itoa returns the integer as a string, an empty string for 0 so the element
+// is omitted
+func itoa(i int) string {
+	if i == 0 {
+		return ""
+	}
+
+	return strconv.Itoa(i)
+}
@@ -0,0 +1,144 @@
+// This file is part of clutch.
+//
+// clutch is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// clutch is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with Foobar.  If not, see <http://www.gnu.org/licenses/>.
+
+package rss
+
+import (
+	"encoding/xml"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestMarshal(t *testing.T) {
+	r := RSS{}
+	r.Channel.Title = "Title & co"
+	r.Channel.Link = "http://example.org/"
+	r.Channel.Description = "<p>Description</p>"
+	r.Channel.ManagingEditor = "editor@example.org (Editor)"
+	r.Channel.WebMaster = "webmaster@example.org (Webmaster)"
+	r.Channel.PubDate = "2006-01-02T15:04:05Z"
+	r.Channel.LastBuildDate = "Mon, 02 Jan 06 15:04 EST"
+	r.Channel.TextInput.Name = "q"
+	r.Channel.Item = []Item{{
+		Title:    "Item",
+		Content:  "<p>Content</p>",
+		GUID:     GUID{Content: "1", IsPermaLink: "false"},
+		PubDate:  "Mon, 02 Jan 2006 15:04:05 +0100",
+		Category: []Category{{Content: "go", Domain: "http://example.org/"}},
+	}}
+
+	out, err := Marshal(&r)
+	if err != nil {
+		t.Fatalf("[RSS][Marshal] %s", err)
+	}
+
+	var expected = []string{
+		`<?xml version="1.0" encoding="UTF-8"?>`,
+		`<rss version="2.0" xmlns:content="http://purl.org/rss/1.0/modules/content/">`,
+		`<title>Title &amp; co</title>`,
+		`<description>&lt;p&gt;Description&lt;/p&gt;</description>`,
+		`<managingEditor>editor@example.org (Editor)</managingEditor>`,
+		`<webMaster>webmaster@example.org (Webmaster)</webMaster>`,
+		`<pubDate>Mon, 02 Jan 2006 15:04:05 +0000</pubDate>`,
+		`<lastBuildDate>Mon, 02 Jan 2006 15:04:00 EST</lastBuildDate>`,
+		`<textInput>`,
+		`<guid isPermaLink="false">1</guid>`,
+		`<pubDate>Mon, 02 Jan 2006 15:04:05 +0100</pubDate>`,
+		`<category domain="http://example.org/">go</category>`,
+		`<content:encoded>&lt;p&gt;Content&lt;/p&gt;</content:encoded>`,
+	}
+
+	for _, e := range expected {
+		if !strings.Contains(string(out), e) {
+			t.Errorf("[RSS][Marshal] expected '%s' in\n%s", e, out)
+		}
+	}
+
+	if strings.Contains(string(out), "<image>") {
+		t.Errorf("[RSS][Marshal] empty image is written\n%s", out)
+	}
+}
+
+func TestMarshalRoundTrip(t *testing.T) {
+	r := RSS{Version: Version092}
+	r.Channel.Title = "Title"
+	r.Channel.Link = "http://example.org/"
+	r.Channel.Description = "Description"
+	r.Channel.Item = []Item{{Title: "Item 1", Content: "<p>Item 1</p>"},
+		{Description: "Item 2"}}
+	r.Channel.Item[1].DublinCore.Creator = []string{"John Doe"}
+	r.Channel.Item[1].Media.Keywords = []string{"go"}
+	r.Channel.ITunes.Author = "John Doe"
+	r.Channel.LiveItem = []LiveItem{{Item: Item{Title: "Live"},
+		Start: "2021-09-26T07:30:00.000-0600", Status: "live"}}
+	r.Channel.LiveItem[0].Podcast.Chapters.URL = "http://example.org/live.json"
+	r.Channel.Extensions.Element(xml.Name{
+		Space: "http://rssnamespace.org/feedburner/ext/1.0", Local: "info"})
+
+	out, err := Marshal(&r)
+	if err != nil {
+		t.Fatalf("[RSS][Marshal] %s", err)
+	}
+
+	actual, err := Parse(out)
+	if err != nil {
+		t.Fatalf("[RSS][Marshal] output impossible to parse : %s\n%s", err, out)
+	}
+
+	if actual.Version != Version092 ||
+		actual.Channel.Link != "http://example.org/" ||
+		len(actual.Channel.Item) != 2 ||
+		actual.Channel.Item[0].Content != "<p>Item 1</p>" ||
+		actual.Channel.Item[1].Description != "Item 2" ||
+		!reflect.DeepEqual(actual.Channel.Item[1].DublinCore,
+			r.Channel.Item[1].DublinCore) {
+		t.Errorf("[RSS][Marshal] unexpected %+v", actual)
+	}
+
+	if !reflect.DeepEqual(actual.Channel.Extensions, r.Channel.Extensions) {
+		t.Errorf("[RSS][Marshal] extensions : expected %+v, actual %+v",
+			r.Channel.Extensions, actual.Channel.Extensions)
+	}
+
+	if !reflect.DeepEqual(actual.Channel.LiveItem, r.Channel.LiveItem) {
+		t.Errorf("[RSS][Marshal] liveItem : expected %+v, actual %+v\n%s",
+			r.Channel.LiveItem, actual.Channel.LiveItem, out)
+	}
+
+	var expected = []string{
+		`xmlns:dc="http://purl.org/dc/elements/1.1/"`,
+		`<dc:creator>John Doe</dc:creator>`,
+		`<media:keywords>go</media:keywords>`,
+		`<itunes:author>John Doe</itunes:author>`,
+		`<podcast:liveItem status="live" start="2021-09-26T07:30:00.000-0600">`,
+		`<podcast:chapters url="http://example.org/live.json">`,
+	}
+
+	for _, e := range expected {
+		if !strings.Contains(string(out), e) {
+			t.Errorf("[RSS][Marshal] expected '%s' in\n%s", e, out)
+		}
+	}
+
+	if strings.Contains(string(out), "xmlns:dcterms") {
+		t.Errorf("[RSS][Marshal] unused prefix is declared\n%s", out)
+	}
+
+	if n := strings.Count(string(out), "xmlns:podcast="); n != 1 {
+		t.Errorf("[RSS][Marshal] expected the podcast prefix declared once, "+
+			"actual %d\n%s", n, out)
+	}
+}
@@ -15,34 +15,69 @@
 
 package rss
 
-import "encoding/xml"
+import (
+	"bytes"
+	"encoding/xml"
+	"io"
+	"strings"
 
-// rssDeclaration is a simple struct to test if the xml seems to be an rss
-// document
-type rssDeclaration struct {
-	Version string   `xml:"version,attr"`
-	XMLName xml.Name `xml:"rss"`
-}
+	"github.com/racam/clutch/internal/xmldec"
+)
 
-// IsDeclared tries to find a RSS element at the root of the xml document and
-// a version attribute equal to 2.0
+// IsDeclared tries to find a RSS element, whatever its case, at the root of
+// the xml document and a version attribute equal to 0.91, 0.92, 0.93, 0.94 or
+// 2.0. Only the root element is read, the rest of the document is not checked.
 // source : https://cyber.law.harvard.edu/rss/rss.html#whatIsRss
 func IsDeclared(data []byte) bool {
-	r := rssDeclaration{}
-	err := xml.Unmarshal(data, &r)
-
-	if err != nil {
+	start, err := xmldec.RootElement(xmldec.NewDecoder(bytes.NewReader(data)))
+	if err != nil || !strings.EqualFold(start.Name.Local, "rss") {
 		return false
 	}
 
-	return r.Version == "2.0"
+	_, ok := VersionSpec(DeclaredVersion(start))
+	return ok
+}
+
+// DeclaredVersion returns the version attribute of the rss element start,
+// whatever its case, without the surrounding spaces. An empty string is
+// returned if there is none.
+func DeclaredVersion(start xml.StartElement) string {
+	var version string
+	for _, a := range canonicalAttr(start).Attr {
+		if a.Name.Space == "" && a.Name.Local == "version" {
+			version = a.Value
+		}
+	}
+
+	return strings.TrimSpace(version)
 }
 
-// Parse parses the RSS-encoded data into an RSS struct and return it
+// Parse parses the RSS-encoded data into an RSS struct and return it, see
+// ParseReader
 func Parse(data []byte) (*RSS, error) {
+	return ParseReader(bytes.NewReader(data))
+}
+
+// ParseReader parses the RSS document read from r into an RSS struct and
+// return it. The document is decoded in a single pass, without being buffered.
+func ParseReader(r io.Reader) (*RSS, error) {
+	d := xmldec.NewDecoder(r)
+
+	start, err := xmldec.RootElement(d)
+	if err != nil {
+		return &RSS{}, err
+	}
+
+	return ParseElement(d, start)
+}
+
+// ParseElement parses the RSS document of the decoder, whose root element
+// start has just been read. It lets a caller which has read the root element
+// to detect the format continue the decoding.
+func ParseElement(d *xml.Decoder, start xml.StartElement) (*RSS, error) {
 	r := RSS{}
 
-	err := xml.Unmarshal(data, &r)
+	err := d.DecodeElement(&r, &start)
 
 	return &r, err
 }
@@ -16,6 +16,8 @@
 package rss
 
 import (
+	"encoding/xml"
+	"errors"
 	"io/ioutil"
 	"testing"
 )
@@ -28,8 +30,11 @@ func TestIsDeclared(t *testing.T) {
 		expected bool   // expected result
 	}{
 		{"unit_01_IsDeclared.xml", true},
-		{"unit_02_IsDeclared.xml", false},
+		{"unit_02_IsDeclared.xml", true},
 		{"unit_03_IsDeclared.xml", false},
+		{"unit_04_IsDeclared.xml", true},
+		{"unit_05_IsDeclared.xml", false},
+		{"unit_16_root_casing.xml", true},
 	}
 
 	for _, ns := range namespaces {
@@ -50,3 +55,87 @@ func TestIsDeclared(t *testing.T) {
 		}
 	}
 }
+
+func TestParseNotRSS(t *testing.T) {
+	filename := "../testdata/atom/unit_01_IsDeclared.xml"
+	data, err := ioutil.ReadFile(filename)
+	if err != nil {
+		t.Fatalf("[RSS][Unit][Parse] file '%s' : is missing", filename)
+	}
+
+	var e xml.UnmarshalError
+	if _, err := Parse(data); !errors.As(err, &e) {
+		t.Errorf("[RSS][Unit][Parse] file '%s' : expected an "+
+			"xml.UnmarshalError, actual %v", filename, err)
+	}
+
+	filename = "../testdata/rss/unit_16_root_casing.xml"
+	data, err = ioutil.ReadFile(filename)
+	if err != nil {
+		t.Fatalf("[RSS][Unit][Parse] file '%s' : is missing", filename)
+	}
+
+	if r, err := Parse(data); err != nil || r.Channel.Title != "Title" {
+		t.Errorf("[RSS][Unit][Parse] file '%s' : unexpected %+v, %v",
+			filename, r, err)
+	}
+}
+
+func TestVersionSpec(t *testing.T) {
+	var versions = []struct {
+		version  string // input version
+		expected bool   // expected result
+		language bool   // expected RequiredLanguage
+		encl     bool   // expected Enclosure
+	}{
+		{"0.91", true, true, false},
+		{"0.92", true, false, true},
+		{"0.93", true, false, true},
+		{"0.94", true, false, true},
+		{"2.0", true, false, true},
+		{"0.90", false, false, false},
+		{"1.0", false, false, false},
+	}
+
+	for _, v := range versions {
+		s, ok := VersionSpec(v.version)
+
+		if ok != v.expected {
+			t.Errorf("[RSS][Unit][VersionSpec] version '%s' : expected '%t', "+
+				"actual '%t'", v.version, v.expected, ok)
+			continue
+		}
+
+		if s.RequiredLanguage != v.language || s.Enclosure != v.encl {
+			t.Errorf("[RSS][Unit][VersionSpec] version '%s' : unexpected spec "+
+				"%+v", v.version, s)
+		}
+	}
+}
+
+func TestSpecHour(t *testing.T) {
+	var hours = []struct {
+		version  string // input version
+		hour     int    // input hour
+		expected int    // expected hour
+		valid    bool   // expected validity
+	}{
+		{"0.91", 24, 0, true},
+		{"0.91", 1, 1, true},
+		{"0.91", 0, 0, false},
+		{"2.0", 0, 0, true},
+		{"2.0", 23, 23, true},
+		{"2.0", 24, 0, false},
+	}
+
+	for _, h := range hours {
+		s, _ := VersionSpec(h.version)
+		res, ok := s.Hour(h.hour)
+
+		if ok != h.valid || res != h.expected {
+			t.Errorf("[RSS][Unit][Hour] version '%s' hour %d : expected "+
+				"(%d, %t), actual (%d, %t)", h.version, h.hour, h.expected,
+				h.valid, res, ok)
+		}
+	}
+}
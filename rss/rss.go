@@ -18,8 +18,21 @@ package rss
 
 import (
 	"encoding/xml"
+	"time"
+
+	"github.com/racam/clutch/content"
+	"github.com/racam/clutch/dc"
+	"github.com/racam/clutch/ext"
+	"github.com/racam/clutch/itunes"
+	"github.com/racam/clutch/media"
+	"github.com/racam/clutch/podcast"
 )
 
+// NamespaceContent is the namespace of the content module, its encoded element
+// holds the full content of an item as escaped HTML
+// source : https://web.resource.org/rss/1.0/modules/content/
+const NamespaceContent string = content.Namespace
+
 // RSS is a RSS structure like describe in
 // https://cyber.law.harvard.edu/rss/rss.html#whatIsRss
 type RSS struct {
@@ -31,52 +44,78 @@ type RSS struct {
 // Channel is a RSS structure like describe in
 // https://cyber.law.harvard.edu/rss/rss.html#requiredChannelElements
 type Channel struct {
-	Category       []Category `xml:"category"`
-	Cloud          string     `xml:"cloud"`
-	Copyright      string     `xml:"copyright"`
-	Description    string     `xml:"description"`
-	Docs           string     `xml:"docs"`
-	Generator      string     `xml:"generator"`
-	Image          Image      `xml:"image"`
-	Item           []Item     `xml:"item"`
-	Language       string     `xml:"language"`
-	ManagingEditor string     `xml:"managingeditor"`
-	Link           string     `xml:"link"`
-	LastBuildDate  string     `xml:"lastbuilddate"`
-	PubDate        string     `xml:"pubdate"`
-	Rating         string     `xml:"rating"`
-	SkipDays       string     `xml:"skipdays"`
-	SkipHours      string     `xml:"skiphours"`
-	TextInput      TextInput  `xml:"textinput"`
-	Title          string     `xml:"source"`
-	TTL            string     `xml:"ttl"`
-	WebMaster      string     `xml:"webmaster"`
+	Category       []Category      `xml:"category"`
+	Cloud          Cloud           `xml:"cloud"`
+	Copyright      string          `xml:"copyright"`
+	Description    string          `xml:"description"`
+	Docs           string          `xml:"docs"`
+	DublinCore     dc.DublinCore   `xml:"-"` //Fill with the dc elements
+	Extensions     ext.Extensions  `xml:"-"` //Fill with the unknown elements
+	Generator      string          `xml:"generator"`
+	ITunes         itunes.Channel  `xml:"-"` //Fill with the itunes elements
+	Image          Image           `xml:"image"`
+	InvalidHours   []string        `xml:"-"` //Fill with the hours which are not integers
+	InvalidTTL     string          `xml:"-"` //Fill with the ttl if it is not an integer
+	Item           []Item          `xml:"item"`
+	Language       string          `xml:"language"`
+	ManagingEditor string          `xml:"managingEditor"`
+	Link           string          `xml:"link"`
+	LastBuildDate  string          `xml:"lastBuildDate"`
+	LiveItem       []LiveItem      `xml:"-"` //Fill with the podcast:liveItem elements
+	Modules        ext.Modules     `xml:"-"` //Fill with the registered modules
+	Podcast        podcast.Channel `xml:"-"` //Fill with the podcast elements
+	PubDate        string          `xml:"pubDate"`
+	Rating         string          `xml:"rating"`
+	SkipDays       []time.Weekday  `xml:"skipDays>day"`
+	SkipHours      []int           `xml:"skipHours>hour"`
+	TextInput      TextInput       `xml:"textInput"`
+	Title          string          `xml:"title"`
+	TTL            int             `xml:"ttl"` // minutes
+	WebMaster      string          `xml:"webMaster"`
 }
 
 // Item is a RSS structure like describe in
 // https://cyber.law.harvard.edu/rss/rss.html#hrelementsOfLtitemgt
 type Item struct {
-	Author      string     `xml:"author"`
-	Category    []Category `xml:"category"`
-	Comments    string     `xml:"comments"`
-	Description string     `xml:"description"`
-	Enclosure   string     `xml:"enclosure"`
-	GUID        GUID       `xml:"guid"`
-	Link        string     `xml:"link"`
-	PubDate     string     `xml:"pubdate"`
-	Source      Source     `xml:"source"`
-	Title       string     `xml:"title"`
+	Author      string         `xml:"author"`
+	Category    []Category     `xml:"category"`
+	Comments    string         `xml:"comments"`
+	Content     string         `xml:"http://purl.org/rss/1.0/modules/content/ encoded"`
+	Description string         `xml:"description"`
+	DublinCore  dc.DublinCore  `xml:"-"` //Fill with the dc elements
+	Enclosure   Enclosure      `xml:"enclosure"`
+	Extensions  ext.Extensions `xml:"-"` //Fill with the unknown elements
+	GUID        GUID           `xml:"guid"`
+	ITunes      itunes.Item    `xml:"-"` //Fill with the itunes elements
+	Link        string         `xml:"link"`
+	Media       media.Media    `xml:"-"` //Fill with the media elements
+	Modules     ext.Modules    `xml:"-"` //Fill with the registered modules
+	Podcast     podcast.Item   `xml:"-"` //Fill with the podcast elements
+	PubDate     string         `xml:"pubDate"`
+	Source      Source         `xml:"source"`
+	Title       string         `xml:"title"`
+}
+
+// LiveItem is a Podcasting 2.0 structure like describe in
+// https://podcastindex.org/namespace/1.0#live-item
+// It is an item of a live stream, which sits alongside the items of the
+// channel.
+type LiveItem struct {
+	Item
+	End    string
+	Start  string
+	Status string // pending, live or ended
 }
 
 // Image is a RSS structure like describe in
 // https://cyber.law.harvard.edu/rss/rss.html#ltimagegtSubelementOfLtchannelgt
 type Image struct {
 	Description string `xml:"description"`
-	Height      string `xml:"height"`
+	Height      int    `xml:"height"`
 	Link        string `xml:"link"`
 	Title       string `xml:"title"`
 	URL         string `xml:"url"`
-	Width       string `xml:"width"`
+	Width       int    `xml:"width"`
 }
 
 // TextInput is a RSS structure like describe in
@@ -102,9 +141,29 @@ type Category struct {
 	Domain  string `xml:"domain,attr"`
 }
 
+// Cloud is a RSS structure like describe in
+// https://cyber.law.harvard.edu/rss/rss.html#ltcloudgtSubelementOfLtchannelgt
+type Cloud struct {
+	Domain            string `xml:"domain,attr"`
+	Path              string `xml:"path,attr"`
+	Port              int    `xml:"port,attr"`
+	Protocol          string `xml:"protocol,attr"`
+	RegisterProcedure string `xml:"registerProcedure,attr"`
+}
+
+// Enclosure is a RSS structure like describe in
+// https://cyber.law.harvard.edu/rss/rss.html#ltenclosuregtSubelementOfLtitemgt
+type Enclosure struct {
+	InvalidLength string `xml:"-"`           //Fill with the length if it is not an integer
+	Length        int64  `xml:"length,attr"` // bytes
+	MissingLength bool   `xml:"-"`           //Fill when the length is missing
+	Type          string `xml:"type,attr"`
+	URL           string `xml:"url,attr"`
+}
+
 // GUID is a RSS structure like describe in
 // https://cyber.law.harvard.edu/rss/rss.html#ltguidgtSubelementOfLtitemgt
 type GUID struct {
 	Content     string `xml:",chardata"`
-	IsPermaLink string `xml:"ispermalink,attr"`
+	IsPermaLink string `xml:"isPermaLink,attr"`
 }
@@ -0,0 +1,73 @@
+// This file is part of clutch.
+//
+// clutch is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// clutch is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with Foobar.  If not, see <http://www.gnu.org/licenses/>.
+
+package rss
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestParser(t *testing.T) {
+	files, _ := filepath.Glob("../testdata/rss/integ_*.xml")
+	for _, file := range files {
+
+		base := filepath.Base(file)
+		name := strings.TrimSuffix(base, filepath.Ext(base))
+
+		// Get actual source feed
+		filename := fmt.Sprintf("../testdata/rss/%s.xml", name)
+		rssContent, err := ioutil.ReadFile(filename)
+		if err != nil {
+			t.Errorf("[RSS][Integ] %s", err)
+			continue
+		}
+
+		// Get encoded expected feed result
+		filename = fmt.Sprintf("../testdata/rss/%s.json", name)
+		jsonContent, err := ioutil.ReadFile(filename)
+		if err != nil {
+			t.Errorf("[RSS][Integ] %s", err)
+			continue
+		}
+
+		// Parse actual xml feed
+		actual, err := Parse(rssContent)
+		if err != nil {
+			t.Errorf("[RSS][Integ] file %s.xml impossible to parse : %s", name, err)
+			continue
+		}
+
+		// Parse expected json feed
+		var expected RSS
+		err = json.Unmarshal(jsonContent, &expected)
+		if err != nil {
+			t.Errorf("[RSS][Integ] file %s.json impossible to parse : %s", name, err)
+			continue
+		}
+
+		// Becareful actual is a pointer
+		if !reflect.DeepEqual(actual, &expected) {
+			t.Errorf("[RSS][Integ] file %s, xml and json don't match", name)
+			t.Logf("[DEBUG]%+v", actual)
+			t.Logf("[DEBUG]%+v", &expected)
+		}
+	}
+}
@@ -0,0 +1,83 @@
+// This file is part of clutch.
+//
+// clutch is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// clutch is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with Foobar.  If not, see <http://www.gnu.org/licenses/>.
+
+package rss
+
+// Versions of RSS understood by this package. RSS 0.90 and RSS 1.0 are RDF
+// documents, they are handled by the rdf package.
+const (
+	Version091 string = "0.91"
+	Version092 string = "0.92"
+	Version093 string = "0.93"
+	Version094 string = "0.94"
+	Version20  string = "2.0"
+)
+
+// Spec describes the rules that differ between the versions of RSS
+type Spec struct {
+	// Version is the value of the version attribute of the rss element
+	Version string
+	// RequiredLanguage is true if the channel MUST contain a language element
+	RequiredLanguage bool
+	// Enclosure is true if an item MAY contain an enclosure element. When it
+	// is allowed, the url, length and type attributes are required.
+	Enclosure bool
+	// MinHour and MaxHour are the bounds of the hour elements of skipHours
+	MinHour int
+	MaxHour int
+}
+
+// specs lists the rules of each version
+// sources :
+// * 0.91 : http://backend.userland.com/rss091
+// * 0.92 : http://backend.userland.com/rss092
+// * 0.93 and 0.94 : drafts that kept the 0.92 rules
+// * 2.0 : https://cyber.law.harvard.edu/rss/rss.html
+var specs = map[string]Spec{
+	// In RSS 0.91 the hours of skipHours go from 1 to 24, 24 being midnight
+	Version091: {Version091, true, false, 1, 24},
+	Version092: {Version092, false, true, 0, 23},
+	Version093: {Version093, false, true, 0, 23},
+	Version094: {Version094, false, true, 0, 23},
+	Version20:  {Version20, false, true, 0, 23},
+}
+
+// VersionSpec returns the rules of the given version of RSS. The boolean is
+// false if the version is not supported.
+func VersionSpec(version string) (Spec, bool) {
+	s, ok := specs[version]
+	return s, ok
+}
+
+// Spec returns the rules of the version declared by the document. Unknown
+// versions fall back on the RSS 2.0 rules.
+func (r *RSS) Spec() Spec {
+	if s, ok := VersionSpec(r.Version); ok {
+		return s
+	}
+
+	return specs[Version20]
+}
+
+// Hour converts an hour of skipHours, as written in a document of this
+// version, to an hour between 0 and 23. The boolean is false if the hour is
+// out of the range allowed by the version.
+func (s Spec) Hour(hour int) (int, bool) {
+	if hour < s.MinHour || hour > s.MaxHour {
+		return 0, false
+	}
+
+	return hour % 24, true
+}
@@ -17,6 +17,10 @@ package clutch
 
 import (
 	"github.com/racam/clutch/atom"
+	"github.com/racam/clutch/ext"
+	"github.com/racam/clutch/itunes"
+	"github.com/racam/clutch/jsonfeed"
+	"github.com/racam/clutch/rdf"
 	"github.com/racam/clutch/rss"
 )
 
@@ -33,8 +37,28 @@ const (
 	FeedTypeAtom
 	// FeedTypeRSS represents an RSS feed
 	FeedTypeRSS
+	// FeedTypeRDF represents an RSS 1.0 (RDF Site Summary) feed
+	FeedTypeRDF
+	// FeedTypeJSON represents a JSON Feed
+	FeedTypeJSON
 )
 
+// String returns the name of the format
+func (t FeedType) String() string {
+	switch t {
+	case FeedTypeAtom:
+		return "Atom"
+	case FeedTypeRSS:
+		return "RSS"
+	case FeedTypeRDF:
+		return "RSS 1.0"
+	case FeedTypeJSON:
+		return "JSON Feed"
+	}
+
+	return "unknown"
+}
+
 // Feed is the root element of this common structure for RSS/Atom.
 // It has few metadata and contains all the 'entry'
 type Feed struct {
@@ -42,16 +66,22 @@ type Feed struct {
 	Category    []*string
 	Description *string
 	Entry       []Entry
+	Extensions  ext.Extensions // unknown elements of RSS and Atom, see ext
 	Generator   *string
+	ITunes      *itunes.Channel // nil when the feed is not RSS
 	Language    *string
 	Logo        *string
 	Link        *string
+	Modules     ext.Modules // registered modules of RSS, RDF and Atom, see ext
 	Rights      *string
 	Title       *string
 	Updated     *string
 	RSS         *rss.RSS
+	RDF         *rdf.RDF
 	Atom        *atom.Feed
+	JSON        *jsonfeed.Feed
 	FeedType    FeedType
+	Version     string // exact version of the format, e.g. "0.91" for RSS
 }
 
 // Entry is the principal element of this common structure for RSS/Atom.
@@ -60,12 +90,91 @@ type Feed struct {
 type Entry struct {
 	Author      *string
 	Category    []*string
+	Chapters    *string // URL of the chapters of an episode
+	Content     Content
 	Description *string
+	Enclosures  []Enclosure
+	Extensions  ext.Extensions
 	ID          *string
+	ITunes      *itunes.Item // nil when the feed is not RSS
 	Link        *string
+	Modules     ext.Modules
 	Published   *string
 	Source      Source
+	Thumbnails  []Thumbnail
 	Title       *string
+	Transcripts []Transcript
+}
+
+// ContentType is the kind of the content of an entry
+type ContentType int
+
+const (
+	// ContentTypeNone represents an entry without content
+	ContentTypeNone ContentType = iota
+	// ContentTypeText represents plain text
+	ContentTypeText
+	// ContentTypeHTML represents escaped HTML
+	ContentTypeHTML
+	// ContentTypeXHTML represents XHTML, wrapped in a div element
+	ContentTypeXHTML
+	// ContentTypeSrc represents an out-of-line content, only its URI is known
+	ContentTypeSrc
+	// ContentTypeMedia represents an inline content of another media type,
+	// XML or text as it is, Base64 encoded otherwise
+	ContentTypeMedia
+)
+
+// String returns the name of the content type, like the Atom type attribute
+func (t ContentType) String() string {
+	switch t {
+	case ContentTypeText:
+		return "text"
+	case ContentTypeHTML:
+		return "html"
+	case ContentTypeXHTML:
+		return "xhtml"
+	case ContentTypeSrc:
+		return "src"
+	case ContentTypeMedia:
+		return "media"
+	}
+
+	return "none"
+}
+
+// Content is the full content of an entry, when the description is only a
+// teaser. It is an Atom content, a RSS content:encoded or a JSON Feed content.
+type Content struct {
+	MIMEType *string // media type of ContentTypeSrc and ContentTypeMedia
+	Src      *string // URI of ContentTypeSrc
+	Type     ContentType
+	Value    *string
+}
+
+// Enclosure is a media object attached to an entry, e.g. the audio file of a
+// podcast episode. It is a RSS enclosure, an Atom link with the enclosure
+// relation, a Media RSS content or a JSON Feed attachment.
+type Enclosure struct {
+	Length   *int64 // bytes, 0 when unknown
+	MIMEType *string
+	URL      *string
+}
+
+// Thumbnail is an image representing an entry, e.g. the preview of a video.
+// It is a Media RSS thumbnail of a RSS item or an Atom entry.
+type Thumbnail struct {
+	Height *int // pixels, 0 when unknown
+	URL    *string
+	Width  *int // pixels, 0 when unknown
+}
+
+// Transcript is the transcript or the captions of an episode. It is a
+// Podcasting 2.0 transcript of a RSS item.
+type Transcript struct {
+	Language *string
+	MIMEType *string
+	URL      *string
 }
 
 // Source is useful if an entry is forwarded from an existing RSS/Atom feed
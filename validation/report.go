@@ -0,0 +1,133 @@
+// This file is part of clutch.
+//
+// clutch is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// clutch is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with Foobar.  If not, see <http://www.gnu.org/licenses/>.
+
+// Package validation holds the report shared by the validators of the atom
+// and rss packages, so the violations of both formats are read the same way.
+package validation
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Severity tells if a violation breaks a MUST rule of the specification or
+// only a SHOULD rule or a best practice
+type Severity int
+
+const (
+	// SeverityError is the severity of the MUST rules
+	SeverityError Severity = iota
+	// SeverityWarning is the severity of the SHOULD rules and best practices
+	SeverityWarning
+)
+
+// String returns "error" or "warning"
+func (s Severity) String() string {
+	if s == SeverityWarning {
+		return "warning"
+	}
+
+	return "error"
+}
+
+// Violation is a rule of a specification that the document does not respect.
+// Path locates the element like an XPath, e.g. /feed/entry[3]/author[1]/name,
+// the repeatable elements have an index starting at 1. Line and Column are the
+// position of the element in the source, or of its closest parent when the
+// element is missing. They are 0 when the source is unknown.
+type Violation struct {
+	Column   int
+	Line     int
+	Message  string
+	Path     string
+	Section  string // section of the specification, e.g. "4.1.2"
+	Severity Severity
+	Spec     string // specification of the rule, e.g. "RFC 4287"
+}
+
+// Error returns the violation as a line of a lint report
+func (v *Violation) Error() string {
+	var b strings.Builder
+
+	if v.Line > 0 {
+		fmt.Fprintf(&b, "%d:%d: ", v.Line, v.Column)
+	}
+	fmt.Fprintf(&b, "%s: %s: %s", v.Severity, v.Path, v.Message)
+	if v.Section != "" {
+		fmt.Fprintf(&b, " (%s section %s)", v.Spec, v.Section)
+	} else if v.Spec != "" {
+		fmt.Fprintf(&b, " (%s)", v.Spec)
+	}
+
+	return b.String()
+}
+
+// Report lists the violations of a document in the order of the document
+type Report struct {
+	Violations []Violation
+}
+
+// Add appends a violation to the report
+func (r *Report) Add(v Violation) {
+	r.Violations = append(r.Violations, v)
+}
+
+// Err returns the first violation of a MUST rule, nil if there is none
+func (r *Report) Err() error {
+	for index := range r.Violations {
+		if r.Violations[index].Severity == SeverityError {
+			return &r.Violations[index]
+		}
+	}
+
+	return nil
+}
+
+// Errors returns the violations of the MUST rules
+func (r *Report) Errors() []Violation {
+	return r.filter(SeverityError)
+}
+
+// Warnings returns the violations of the SHOULD rules and best practices
+func (r *Report) Warnings() []Violation {
+	return r.filter(SeverityWarning)
+}
+
+// Valid returns true if no MUST rule is violated, the warnings are allowed
+func (r *Report) Valid() bool {
+	return r.Err() == nil
+}
+
+// String returns the violations, one per line
+func (r *Report) String() string {
+	lines := make([]string, len(r.Violations))
+	for index := range r.Violations {
+		lines[index] = r.Violations[index].Error()
+	}
+
+	return strings.Join(lines, "\n")
+}
+
+func (r *Report) filter(severity Severity) []Violation {
+	var res []Violation
+
+	for _, v := range r.Violations {
+		if v.Severity == severity {
+			res = append(res, v)
+		}
+	}
+
+	return res
+}
@@ -0,0 +1,52 @@
+// This file is part of clutch.
+//
+// clutch is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// clutch is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with Foobar.  If not, see <http://www.gnu.org/licenses/>.
+
+package validation
+
+import "testing"
+
+func TestReport(t *testing.T) {
+	var r Report
+
+	if !r.Valid() || r.Err() != nil {
+		t.Errorf("[Validation][Unit][Report] empty report : expected valid")
+	}
+
+	r.Add(Violation{Message: "should", Path: "/rss/channel/ttl",
+		Severity: SeverityWarning, Spec: "RSS 2.0"})
+	r.Add(Violation{Column: 5, Line: 3, Message: "must", Path: "/feed",
+		Section: "4.1.1", Spec: "RFC 4287"})
+
+	if r.Valid() {
+		t.Errorf("[Validation][Unit][Report] expected invalid")
+	}
+
+	if len(r.Errors()) != 1 || len(r.Warnings()) != 1 {
+		t.Errorf("[Validation][Unit][Report] expected 1 error and 1 warning, "+
+			"actual %d and %d", len(r.Errors()), len(r.Warnings()))
+	}
+
+	if err := r.Err(); err == nil || err.Error() != "3:5: error: /feed: "+
+		"must (RFC 4287 section 4.1.1)" {
+		t.Errorf("[Validation][Unit][Err] unexpected %v", err)
+	}
+
+	expected := "warning: /rss/channel/ttl: should (RSS 2.0)\n" +
+		"3:5: error: /feed: must (RFC 4287 section 4.1.1)"
+	if r.String() != expected {
+		t.Errorf("[Validation][Unit][String] expected '%s', actual '%s'",
+			expected, r.String())
+	}
+}